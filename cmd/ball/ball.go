@@ -1,46 +1,40 @@
 package main
 
 import (
+	"fmt"
+	"math"
+	"os"
+
 	rt "github.com/gumuz/go-raytracer/raytracer"
 )
 
 func main() {
-	rayOrigin := rt.NewPoint(0, 0, -5)
-	wallZ := 10.0
-	wallSize := 7.0
-	canvasPixels := 100
-	pixelSize := wallSize / float64(canvasPixels)
-	half := wallSize / 2
-
-	canvas := rt.NewCanvas(canvasPixels, canvasPixels)
+	world := rt.NewWorld()
+
 	sphere := rt.NewSphere()
-	sphere.Material.Color = &rt.Color{1, 0.7, 1}
-	light := rt.NewPointLight(rt.NewPoint(-10, 10, -10), &rt.Color{1, 1, 1})
+	sphere.Material.Color = &rt.Color{R: 1, G: 0.7, B: 1}
+	world.AddObject(sphere)
+
+	light := rt.NewPointLight(rt.NewPoint(-10, 10, -10), &rt.Color{R: 1, G: 1, B: 1})
+	world.AddLight(light)
 
 	// sphere.SetTransform(rt.Scaling(0.5, 1, 1).Mul(rt.RotationZ(math.Pi / 4)).Mul(rt.Shearing(1, 0, 0, 0, 0, 0)))
 
-	// worldX, worldY := 0.0, 0.0
-	for y := 0; y < canvasPixels; y++ {
-		worldY := half - pixelSize*float64(y)
-		for x := 0; x < canvasPixels; x++ {
-			worldX := -half + pixelSize*float64(x)
-			position := rt.NewPoint(worldX, worldY, wallZ)
-			ray := rt.NewRay(rayOrigin, position.Sub(rayOrigin).Norm())
-			xs := sphere.Intersect(ray)
-			hit := xs.Hit()
-
-			if hit != nil {
-				point := ray.Pos(hit.T)
-				normal := hit.Object.NormalAt(point)
-				eye := ray.Direction.Neg()
-
-				color := hit.Object.GetMaterial().Lighting(light, point, eye, normal)
-
-				canvas.SetAt(x, y, color)
-			}
-		}
+	camera := rt.NewCamera(100, 100, math.Pi/4)
+	camera.SetTransform(rt.ViewTransform(
+		rt.NewPoint(0, 0, -5),
+		rt.NewPoint(0, 0, 0),
+		rt.NewVector(0, 1, 0),
+	))
+
+	canvas := camera.RenderWithOptions(world, rt.RenderOptions{
+		Samples: 16,
+		Sampler: rt.JitteredSampler{},
+		Filter:  rt.TentFilter{},
+	})
+
+	if err := canvas.Save("ball.png"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-
-	canvas.Save("ball.png")
-
 }
@@ -1,21 +1,24 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	rt "github.com/gumuz/go-raytracer/raytracer"
 )
 
 func main() {
 	projectile := struct {
-		position *rt.Tuple
-		velocity *rt.Tuple
+		position rt.Tuple
+		velocity rt.Tuple
 	}{
 		rt.NewPoint(0, 1, 0),
 		rt.NewVector(1, 1.8, 0).Norm().Mul(11.25),
 	}
 
 	environment := struct {
-		gravity *rt.Tuple
-		wind    *rt.Tuple
+		gravity rt.Tuple
+		wind    rt.Tuple
 	}{
 		rt.NewVector(0, -0.1, 0),
 		rt.NewVector(-0.01, 0, 0),
@@ -24,7 +27,7 @@ func main() {
 	width, height := 900, 550
 
 	canvas := rt.NewCanvas(width, height)
-	white := &rt.Color{1, 1, 1}
+	white := &rt.Color{R: 1, G: 1, B: 1}
 
 	for projectile.position.Y > 0 {
 		canvas.SetAt(int(projectile.position.X), height-int(projectile.position.Y), white)
@@ -33,6 +36,8 @@ func main() {
 		projectile.velocity = projectile.velocity.Add(environment.gravity).Add(environment.wind)
 	}
 
-	canvas.Save("projectile.png")
-
+	if err := canvas.Save("projectile.png"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+type options struct {
+	scene    string
+	output   string
+	format   string
+	width    int
+	height   int
+	samples  int
+	threads  int
+	tile     int
+	sampler  string
+	filter   string
+	seed     int64
+	max      int
+	thresh   float64
+	heatmap  string
+	exposure float64
+	tonemap  string
+	transfer string
+	quiet    bool
+	timeout  time.Duration
+}
+
+func main() {
+	opts, err := parseFlags(os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, opts, os.Stderr); err != nil {
+		stop()
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func parseFlags(args []string) (*options, error) {
+	opts := &options{}
+
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: render [flags] scene.json\n\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&opts.output, "o", "", "output image `file` (default: the scene name with the format's extension)")
+	flags.StringVar(&opts.format, "format", "", "output image format: "+strings.Join(rt.Formats(), ", ")+" (default: from the output extension, else png)")
+	flags.IntVar(&opts.width, "width", 0, "image width, overriding the scene's camera")
+	flags.IntVar(&opts.height, "height", 0, "image height, overriding the scene's camera")
+	flags.IntVar(&opts.samples, "samples", 1, "rays traced per pixel")
+	flags.StringVar(&opts.sampler, "sampler", "grid", "where samples go in a pixel: grid, jittered, random, halton or sobol")
+	flags.StringVar(&opts.filter, "filter", "box", "reconstruction filter: box, tent, gaussian or mitchell")
+	flags.IntVar(&opts.max, "max-samples", 0, "sample noisy pixels adaptively, up to this many rays (default: off)")
+	flags.Float64Var(&opts.thresh, "threshold", rt.DefaultThreshold, "noise level at which adaptive sampling stops refining a pixel")
+	flags.StringVar(&opts.heatmap, "heatmap", "", "also write an image `file` showing the number of samples per pixel")
+	flags.Int64Var(&opts.seed, "seed", 0, "seed for the random numbers used by the sampler")
+	flags.IntVar(&opts.threads, "threads", runtime.NumCPU(), "number of render threads")
+	flags.IntVar(&opts.tile, "tile", rt.DefaultTileSize, "width and height of the tiles handed to the threads")
+	flags.DurationVar(&opts.timeout, "timeout", 0, "give up when rendering takes longer than this (default: no limit)")
+	flags.Float64Var(&opts.exposure, "exposure", 0, "brighten or darken the output by this many stops")
+	flags.StringVar(&opts.tonemap, "tonemap", "clamp", "how colors brighter than white are displayed: clamp, reinhard or aces")
+	flags.StringVar(&opts.transfer, "transfer", "default", "transfer curve of 8 bit output: srgb, linear or default (linear for Netpbm, else srgb)")
+	flags.BoolVar(&opts.quiet, "q", false, "don't print progress and timing")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return nil, errors.New("expected exactly one scene file")
+	}
+	opts.scene = flags.Arg(0)
+
+	if opts.width < 0 || opts.height < 0 {
+		fmt.Fprintln(flags.Output(), "width and height must be positive")
+		return nil, errors.New("invalid resolution")
+	}
+	if opts.max != 0 && opts.max <= opts.samples {
+		fmt.Fprintln(flags.Output(), "max-samples must be larger than samples")
+		return nil, errors.New("invalid max-samples")
+	}
+	if opts.samples < 1 || opts.threads < 1 || opts.tile < 1 {
+		fmt.Fprintln(flags.Output(), "samples, threads and tile must be at least 1")
+		return nil, errors.New("invalid samples, threads or tile")
+	}
+
+	return opts, nil
+}
+
+func run(ctx context.Context, opts *options, log io.Writer) error {
+	if opts.quiet {
+		log = io.Discard
+	}
+
+	start := time.Now()
+	scene, err := rt.LoadScene(opts.scene)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(log, "loaded %s in %v\n", opts.scene, time.Since(start).Round(time.Millisecond))
+
+	format, output := outputFormat(opts)
+	if _, err := rt.EncoderByName(format); err != nil {
+		return err
+	}
+
+	sampler, err := rt.SamplerByName(opts.sampler)
+	if err != nil {
+		return err
+	}
+	filter, err := rt.FilterByName(opts.filter)
+	if err != nil {
+		return err
+	}
+
+	toneMapper, err := rt.ToneMapperByName(opts.tonemap)
+	if err != nil {
+		return err
+	}
+	transfer, err := rt.TransferByName(opts.transfer)
+	if err != nil {
+		return err
+	}
+
+	camera := resize(scene.Camera, opts.width, opts.height)
+
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	counts := &rt.SampleCounts{}
+
+	start = time.Now()
+	percent := -1
+	canvas, err := camera.RenderContext(ctx, scene.World, rt.RenderOptions{
+		Threads:  opts.threads,
+		TileSize: opts.tile,
+		Samples:  opts.samples,
+		Sampler:  sampler,
+		Filter:   filter,
+		Seed:     opts.seed,
+
+		MaxSamples:   opts.max,
+		Threshold:    opts.thresh,
+		SampleCounts: counts,
+
+		Progress: func(p rt.RenderProgress) {
+			if done := p.PixelsDone * 100 / p.PixelsTotal; done != percent {
+				percent = done
+				fmt.Fprintf(log, "\rrendering %dx%d on %d threads: %3d%%, %v left   ",
+					camera.HSize, camera.VSize, opts.threads, percent, p.Remaining.Round(time.Second))
+			}
+		},
+	})
+	fmt.Fprintln(log)
+	if err != nil {
+		return fmt.Errorf("stopped after %v: %v", time.Since(start).Round(time.Millisecond), err)
+	}
+	fmt.Fprintf(log, "rendered in %v, %.1f samples per pixel\n", time.Since(start).Round(time.Millisecond),
+		float64(counts.Total())/float64(len(counts.Counts)))
+
+	canvas.Display = rt.Display{Exposure: opts.exposure, ToneMapper: toneMapper, Transfer: transfer}
+	if err := canvas.SaveAs(output, format); err != nil {
+		return err
+	}
+	fmt.Fprintf(log, "wrote %s\n", output)
+
+	if opts.heatmap != "" {
+		if err := counts.HeatMap().Save(opts.heatmap); err != nil {
+			return err
+		}
+		fmt.Fprintf(log, "wrote %s\n", opts.heatmap)
+	}
+
+	return nil
+}
+
+// outputFormat works out the format and the output file, each defaulting to
+// what the other implies.
+func outputFormat(opts *options) (string, string) {
+	format, output := opts.format, opts.output
+	if format == "" && output != "" {
+		format = rt.FormatFromFilename(output)
+	}
+	if format == "" {
+		format = "png"
+	}
+	if output == "" {
+		extension := strings.SplitN(format, "-", 2)[0]
+		output = strings.TrimSuffix(opts.scene, filepath.Ext(opts.scene)) + "." + extension
+	}
+
+	return format, output
+}
+
+// resize returns a camera with the given resolution looking the same way as
+// camera. A missing width or height follows the aspect ratio of camera.
+func resize(camera *rt.Camera, width, height int) *rt.Camera {
+	if width == 0 && height == 0 {
+		return camera
+	}
+	if width == 0 {
+		width = height * camera.HSize / camera.VSize
+	}
+	if height == 0 {
+		height = width * camera.VSize / camera.HSize
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	resized := rt.NewCamera(width, height, camera.FieldOfView)
+	resized.SetTransform(camera.Transform)
+
+	return resized
+}
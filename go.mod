@@ -1,3 +1,3 @@
 module github.com/gumuz/go-raytracer
 
-go 1.12
+go 1.16
@@ -0,0 +1,128 @@
+package raytracer
+
+import "math"
+
+// DefaultThreshold is the noise level at which adaptive sampling considers
+// a pixel done, a little below what 8 bit output can show.
+const DefaultThreshold = 0.002
+
+// progressive is implemented by samplers whose first samples are spread
+// well over the pixel on their own, so adaptive sampling can stop after
+// any of them.
+type progressive interface {
+	progressive()
+}
+
+func (RandomSampler) progressive() {}
+func (HaltonSampler) progressive() {}
+func (SobolSampler) progressive()  {}
+
+// progressiveSamples returns n samples in an order that adaptive sampling
+// can stop early in. Grid layouts are shuffled so the first samples don't
+// all come from the top of the pixel.
+func progressiveSamples(sampler Sampler, n int, rng *RNG) []SamplePoint {
+	points := sampler.Samples(n, rng)
+	if _, ok := sampler.(progressive); ok {
+		return points
+	}
+
+	for i := len(points) - 1; i > 0; i-- {
+		j := int(rng.Uint64() % uint64(i+1))
+		points[i], points[j] = points[j], points[i]
+	}
+
+	return points
+}
+
+// luminanceStats keeps a running mean and variance of the luminance of the
+// samples of a pixel (Welford's algorithm).
+type luminanceStats struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (s *luminanceStats) add(c *Color) {
+	l := luminance(c)
+
+	s.n++
+	delta := l - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (l - s.mean)
+}
+
+// standardError estimates how far the mean luminance may be off. With fewer
+// than two samples there is no estimate and it is infinite.
+func (s *luminanceStats) standardError() float64 {
+	if s.n < 2 {
+		return math.Inf(1)
+	}
+
+	variance := s.m2 / float64(s.n-1)
+	return math.Sqrt(variance / float64(s.n))
+}
+
+// SampleCounts records how many rays were traced for each pixel of a render.
+type SampleCounts struct {
+	Width, Height int
+	Counts        []int
+}
+
+func NewSampleCounts(width, height int) *SampleCounts {
+	return &SampleCounts{width, height, make([]int, width*height)}
+}
+
+func (s *SampleCounts) Set(x, y, count int) {
+	s.Counts[y*s.Width+x] = count
+}
+
+func (s *SampleCounts) At(x, y int) int {
+	return s.Counts[y*s.Width+x]
+}
+
+// Total returns the number of rays traced for the whole image.
+func (s *SampleCounts) Total() int {
+	total := 0
+	for _, count := range s.Counts {
+		total += count
+	}
+
+	return total
+}
+
+// HeatMap draws the sample counts, from dark blue for the fewest samples
+// through red to yellow for the most.
+func (s *SampleCounts) HeatMap() *Canvas {
+	least, most := math.MaxInt32, 0
+	for _, count := range s.Counts {
+		if count < least {
+			least = count
+		}
+		if count > most {
+			most = count
+		}
+	}
+
+	// The heat colors are picked for the screen, they are not radiance.
+	canvas := NewCanvas(s.Width, s.Height)
+	canvas.Display.Transfer = LinearTransfer
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			t := 0.0
+			if most > least {
+				t = float64(s.At(x, y)-least) / float64(most-least)
+			}
+			canvas.SetAt(x, y, heatColor(t))
+		}
+	}
+
+	return canvas
+}
+
+func heatColor(t float64) *Color {
+	if t < 0.5 {
+		return &Color{2 * t, 0, 0.5 - t}
+	}
+
+	return &Color{1, 2*t - 1, 0}
+}
@@ -0,0 +1,139 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func adaptiveWorld() (*rt.World, *rt.Camera) {
+	w := rt.NewWorld()
+	sphere := rt.NewSphere()
+	sphere.Material.Ambient = 1
+	sphere.Material.Diffuse = 0
+	sphere.Material.Specular = 0
+	w.AddObject(sphere)
+	w.AddLight(rt.NewPointLight(rt.NewPoint(0, 0, -10), &rt.Color{1, 1, 1}))
+
+	c := rt.NewCamera(21, 21, math.Pi/3)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(0, 0, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	return w, c
+}
+
+func TestAdaptiveSampling(t *testing.T) {
+	/* Scenario: Adaptive sampling only refines noisy pixels
+	   Given a flat white sphere on a black background
+	   When it is rendered with 4 to 64 samples per pixel
+	   Then the background and the inside of the sphere get 4 samples
+	     And pixels on the edge of the sphere get more */
+	w, c := adaptiveWorld()
+
+	counts := &rt.SampleCounts{}
+	c.RenderWithOptions(w, rt.RenderOptions{
+		Samples:      4,
+		MaxSamples:   64,
+		Sampler:      rt.JitteredSampler{},
+		SampleCounts: counts,
+	})
+
+	if counts.Width != 21 || counts.Height != 21 {
+		t.Fatalf("Error: %v %v", counts.Width, counts.Height)
+	}
+
+	if n := counts.At(0, 0); n != 4 {
+		t.Errorf("Error: %v", n)
+	}
+
+	if n := counts.At(10, 10); n != 4 {
+		t.Errorf("Error: %v", n)
+	}
+
+	refined := 0
+	for y := 0; y < 21; y++ {
+		for x := 0; x < 21; x++ {
+			n := counts.At(x, y)
+			if n < 4 || n > 64 || n%4 != 0 {
+				t.Errorf("Error: %v %v %v", x, y, n)
+			}
+			if n > 4 {
+				refined++
+			}
+		}
+	}
+
+	if refined == 0 || refined > 21*21/2 {
+		t.Errorf("Error: %v", refined)
+	}
+
+	if counts.Total() >= 21*21*64 {
+		t.Errorf("Error: %v", counts.Total())
+	}
+}
+
+func TestAdaptiveSamplingDeterministic(t *testing.T) {
+	w, c := adaptiveWorld()
+
+	render := func(threads int) (*rt.Canvas, *rt.SampleCounts) {
+		counts := &rt.SampleCounts{}
+		image := c.RenderWithOptions(w, rt.RenderOptions{
+			Threads:      threads,
+			TileSize:     4,
+			Samples:      2,
+			MaxSamples:   32,
+			Sampler:      rt.HaltonSampler{},
+			Seed:         5,
+			SampleCounts: counts,
+		})
+		return image, counts
+	}
+
+	image1, counts1 := render(1)
+	image4, counts4 := render(4)
+
+	for y := 0; y < 21; y++ {
+		for x := 0; x < 21; x++ {
+			if *image1.GetAt(x, y) != *image4.GetAt(x, y) || counts1.At(x, y) != counts4.At(x, y) {
+				t.Fatalf("Error: %v %v", x, y)
+			}
+		}
+	}
+}
+
+func TestSampleCountsWithoutAdaptiveSampling(t *testing.T) {
+	w, c := adaptiveWorld()
+
+	counts := &rt.SampleCounts{}
+	c.RenderWithOptions(w, rt.RenderOptions{Samples: 3, SampleCounts: counts})
+
+	if counts.Total() != 21*21*3 {
+		t.Errorf("Error: %v", counts.Total())
+	}
+}
+
+func TestSampleCountsHeatMap(t *testing.T) {
+	counts := rt.NewSampleCounts(3, 1)
+	counts.Set(0, 0, 4)
+	counts.Set(1, 0, 10)
+	counts.Set(2, 0, 16)
+
+	heatMap := counts.HeatMap()
+
+	examples := []struct {
+		x        int
+		expected *rt.Color
+	}{
+		{0, &rt.Color{0, 0, 0.5}},
+		{1, &rt.Color{1, 0, 0}},
+		{2, &rt.Color{1, 1, 0}},
+	}
+
+	for _, example := range examples {
+		c := heatMap.GetAt(example.x, 0)
+		d := c.Sub(example.expected)
+		if math.Abs(d.R) > 0.01 || math.Abs(d.G) > 0.01 || math.Abs(d.B) > 0.01 {
+			t.Errorf("Error: %v %v", example.x, c)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package raytracer
+
+import "math"
+
+type BoundingBox struct {
+	Min Tuple
+	Max Tuple
+}
+
+func NewBoundingBox(min, max Tuple) *BoundingBox {
+	return &BoundingBox{min, max}
+}
+
+func NewEmptyBoundingBox() *BoundingBox {
+	return NewBoundingBox(
+		NewPoint(math.Inf(1), math.Inf(1), math.Inf(1)),
+		NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+	)
+}
+
+func (b *BoundingBox) AddPoint(p Tuple) {
+	b.Min = NewPoint(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z))
+	b.Max = NewPoint(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z))
+}
+
+func (b *BoundingBox) AddBox(box *BoundingBox) {
+	b.AddPoint(box.Min)
+	b.AddPoint(box.Max)
+}
+
+func (b *BoundingBox) ContainsPoint(p Tuple) bool {
+	return b.Min.X <= p.X && p.X <= b.Max.X &&
+		b.Min.Y <= p.Y && p.Y <= b.Max.Y &&
+		b.Min.Z <= p.Z && p.Z <= b.Max.Z
+}
+
+func (b *BoundingBox) ContainsBox(box *BoundingBox) bool {
+	return b.ContainsPoint(box.Min) && b.ContainsPoint(box.Max)
+}
+
+func (b *BoundingBox) IsFinite() bool {
+	for _, v := range []float64{b.Min.X, b.Min.Y, b.Min.Z, b.Max.X, b.Max.Y, b.Max.Z} {
+		if math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BoundingBox) Centroid() Tuple {
+	return NewPoint(
+		(b.Min.X+b.Max.X)/2,
+		(b.Min.Y+b.Max.Y)/2,
+		(b.Min.Z+b.Max.Z)/2,
+	)
+}
+
+func (b *BoundingBox) Transform(m Matrix) *BoundingBox {
+	if !b.IsFinite() {
+		// Transforming infinite corners produces NaNs, fall back to a box
+		// that bounds everything.
+		return NewBoundingBox(
+			NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+			NewPoint(math.Inf(1), math.Inf(1), math.Inf(1)),
+		)
+	}
+
+	min := [3]float64{math.Inf(1), math.Inf(1), math.Inf(1)}
+	max := [3]float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+
+	for _, x := range []float64{b.Min.X, b.Max.X} {
+		for _, y := range []float64{b.Min.Y, b.Max.Y} {
+			for _, z := range []float64{b.Min.Z, b.Max.Z} {
+				for row := 0; row < 3; row++ {
+					v := m[row][0]*x + m[row][1]*y + m[row][2]*z + m[row][3]
+					min[row] = math.Min(min[row], v)
+					max[row] = math.Max(max[row], v)
+				}
+			}
+		}
+	}
+
+	return NewBoundingBox(NewPoint(min[0], min[1], min[2]), NewPoint(max[0], max[1], max[2]))
+}
+
+func (b *BoundingBox) Intersects(r Ray) bool {
+	xtmin, xtmax := checkAxis(r.Origin.X, r.Direction.X, b.Min.X, b.Max.X)
+	ytmin, ytmax := checkAxis(r.Origin.Y, r.Direction.Y, b.Min.Y, b.Max.Y)
+	ztmin, ztmax := checkAxis(r.Origin.Z, r.Direction.Z, b.Min.Z, b.Max.Z)
+
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+
+	return tmin <= tmax && tmax >= 0
+}
@@ -0,0 +1,378 @@
+package raytracer_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestEmptyBoundingBox(t *testing.T) {
+	/* Scenario: Creating an empty bounding box
+	   Given box ← bounding_box(empty)
+	   Then box.min = point(infinity, infinity, infinity)
+	     And box.max = point(-infinity, -infinity, -infinity) */
+	box := rt.NewEmptyBoundingBox()
+
+	if !math.IsInf(box.Min.X, 1) || !math.IsInf(box.Min.Y, 1) || !math.IsInf(box.Min.Z, 1) {
+		t.Errorf("Error: %v", box.Min)
+	}
+
+	if !math.IsInf(box.Max.X, -1) || !math.IsInf(box.Max.Y, -1) || !math.IsInf(box.Max.Z, -1) {
+		t.Errorf("Error: %v", box.Max)
+	}
+}
+
+func TestBoundingBoxAddPoint(t *testing.T) {
+	/* Scenario: Adding points to an empty bounding box
+	   Given box ← bounding_box(empty)
+	     And p1 ← point(-5, 2, 0)
+	     And p2 ← point(7, 0, -3)
+	   When p1 is added to box
+	     And p2 is added to box
+	   Then box.min = point(-5, 0, -3)
+	     And box.max = point(7, 2, 0) */
+	box := rt.NewEmptyBoundingBox()
+
+	box.AddPoint(rt.NewPoint(-5, 2, 0))
+	box.AddPoint(rt.NewPoint(7, 0, -3))
+
+	if !box.Min.Equals(rt.NewPoint(-5, 0, -3)) {
+		t.Errorf("Error: %v", box.Min)
+	}
+
+	if !box.Max.Equals(rt.NewPoint(7, 2, 0)) {
+		t.Errorf("Error: %v", box.Max)
+	}
+}
+
+func TestShapeBounds(t *testing.T) {
+	/* Scenario: Each primitive has an object-space bounding box */
+	cyl := rt.NewCylinder()
+	cyl.Minimum = -5
+	cyl.Maximum = 3
+	cone := rt.NewCone()
+	cone.Minimum = -5
+	cone.Maximum = 3
+
+	examples := []struct {
+		shape    rt.Shape
+		min, max rt.Tuple
+	}{
+		{rt.NewSphere(), rt.NewPoint(-1, -1, -1), rt.NewPoint(1, 1, 1)},
+		{rt.NewCube(), rt.NewPoint(-1, -1, -1), rt.NewPoint(1, 1, 1)},
+		{cyl, rt.NewPoint(-1, -5, -1), rt.NewPoint(1, 3, 1)},
+		{cone, rt.NewPoint(-5, -5, -5), rt.NewPoint(5, 3, 5)},
+		{rt.NewTriangle(rt.NewPoint(-3, 7, 2), rt.NewPoint(6, 2, -4), rt.NewPoint(2, -1, -1)), rt.NewPoint(-3, -1, -4), rt.NewPoint(6, 7, 2)},
+	}
+
+	for _, example := range examples {
+		box := example.shape.Bounds()
+
+		if !box.Min.Equals(example.min) || !box.Max.Equals(example.max) {
+			t.Errorf("Error: %v %v", box.Min, box.Max)
+		}
+	}
+}
+
+func TestPlaneBounds(t *testing.T) {
+	/* Scenario: A plane has a bounding box
+	   Given shape ← plane()
+	   When box ← bounds_of(shape)
+	   Then box.min = point(-infinity, 0, -infinity)
+	     And box.max = point(infinity, 0, infinity) */
+	box := rt.NewPlane().Bounds()
+
+	if !math.IsInf(box.Min.X, -1) || box.Min.Y != 0 || !math.IsInf(box.Min.Z, -1) {
+		t.Errorf("Error: %v", box.Min)
+	}
+
+	if !math.IsInf(box.Max.X, 1) || box.Max.Y != 0 || !math.IsInf(box.Max.Z, 1) {
+		t.Errorf("Error: %v", box.Max)
+	}
+}
+
+func TestBoundingBoxContainsBox(t *testing.T) {
+	/* Scenario Outline: Checking to see if a box contains a given box
+	   Given box ← bounding_box(min=point(5, -2, 0) max=point(11, 4, 7))
+	     And box2 ← bounding_box(min=<min> max=<max>)
+	   Then box_contains_box(box, box2) is <result> */
+	box := rt.NewBoundingBox(rt.NewPoint(5, -2, 0), rt.NewPoint(11, 4, 7))
+
+	examples := []struct {
+		min, max rt.Tuple
+		result   bool
+	}{
+		{rt.NewPoint(5, -2, 0), rt.NewPoint(11, 4, 7), true},
+		{rt.NewPoint(6, -1, 1), rt.NewPoint(10, 3, 6), true},
+		{rt.NewPoint(4, -3, -1), rt.NewPoint(10, 3, 6), false},
+		{rt.NewPoint(6, -1, 1), rt.NewPoint(12, 5, 8), false},
+	}
+
+	for _, example := range examples {
+		if box.ContainsBox(rt.NewBoundingBox(example.min, example.max)) != example.result {
+			t.Errorf("Error: %v %v", example.min, example.max)
+		}
+	}
+}
+
+func TestBoundingBoxTransform(t *testing.T) {
+	/* Scenario: Transforming a bounding box
+	   Given box ← bounding_box(min=point(-1, -1, -1) max=point(1, 1, 1))
+	     And matrix ← rotation_x(π / 4) * rotation_y(π / 4)
+	   When box2 ← transform(box, matrix)
+	   Then box2.min = point(-1.4142, -1.7071, -1.7071)
+	     And box2.max = point(1.4142, 1.7071, 1.7071) */
+	box := rt.NewBoundingBox(rt.NewPoint(-1, -1, -1), rt.NewPoint(1, 1, 1))
+	matrix := rt.RotationX(math.Pi / 4).Mul(rt.RotationY(math.Pi / 4))
+
+	box2 := box.Transform(matrix)
+
+	if math.Abs(box2.Min.X+1.4142) > 0.0001 || math.Abs(box2.Min.Y+1.7071) > 0.0001 || math.Abs(box2.Min.Z+1.7071) > 0.0001 {
+		t.Errorf("Error: %v", box2.Min)
+	}
+
+	if math.Abs(box2.Max.X-1.4142) > 0.0001 || math.Abs(box2.Max.Y-1.7071) > 0.0001 || math.Abs(box2.Max.Z-1.7071) > 0.0001 {
+		t.Errorf("Error: %v", box2.Max)
+	}
+}
+
+func TestGroupBounds(t *testing.T) {
+	/* Scenario: A group has a bounding box that contains its children
+	   Given s ← sphere()
+	     And set_transform(s, translation(2, 5, -3) * scaling(2, 2, 2))
+	     And c ← cylinder()
+	     And c.minimum ← -2
+	     And c.maximum ← 2
+	     And set_transform(c, translation(-4, -1, 4) * scaling(0.5, 1, 0.5))
+	     And shape ← group()
+	     And add_child(shape, s)
+	     And add_child(shape, c)
+	   When box ← bounds_of(shape)
+	   Then box.min = point(-4.5, -3, -5)
+	     And box.max = point(4, 7, 4.5) */
+	s := rt.NewSphere()
+	s.SetTransform(rt.Translation(2, 5, -3).Mul(rt.Scaling(2, 2, 2)))
+	c := rt.NewCylinder()
+	c.Minimum = -2
+	c.Maximum = 2
+	c.SetTransform(rt.Translation(-4, -1, 4).Mul(rt.Scaling(0.5, 1, 0.5)))
+	shape := rt.NewGroup()
+	shape.AddChild(s, c)
+
+	box := shape.Bounds()
+
+	if !box.Min.Equals(rt.NewPoint(-4.5, -3, -5)) {
+		t.Errorf("Error: %v", box.Min)
+	}
+
+	if !box.Max.Equals(rt.NewPoint(4, 7, 4.5)) {
+		t.Errorf("Error: %v", box.Max)
+	}
+}
+
+func TestGroupBoundsFollowChildTransform(t *testing.T) {
+	/* Scenario: Moving a child after it was added updates the group bounds */
+	s := rt.NewSphere()
+	g := rt.NewGroup()
+	g.AddChild(s)
+	g.Bounds()
+
+	s.SetTransform(rt.Translation(10, 0, 0))
+	box := g.Bounds()
+
+	if !box.Min.Equals(rt.NewPoint(9, -1, -1)) || !box.Max.Equals(rt.NewPoint(11, 1, 1)) {
+		t.Errorf("Error: %v %v", box.Min, box.Max)
+	}
+}
+
+func TestBoundingBoxIntersects(t *testing.T) {
+	/* Scenario Outline: Intersecting a ray with a non-cubic bounding box
+	   Given box ← bounding_box(min=point(5, -2, 0) max=point(11, 4, 7))
+	     And direction ← normalize(<direction>)
+	     And r ← ray(<origin>, direction)
+	   Then intersects(box, r) is <result> */
+	box := rt.NewBoundingBox(rt.NewPoint(5, -2, 0), rt.NewPoint(11, 4, 7))
+
+	examples := []struct {
+		origin, direction rt.Tuple
+		result            bool
+	}{
+		{rt.NewPoint(15, 1, 2), rt.NewVector(-1, 0, 0), true},
+		{rt.NewPoint(-5, -1, 4), rt.NewVector(1, 0, 0), true},
+		{rt.NewPoint(7, 6, 5), rt.NewVector(0, -1, 0), true},
+		{rt.NewPoint(9, -5, 6), rt.NewVector(0, 1, 0), true},
+		{rt.NewPoint(8, 2, 12), rt.NewVector(0, 0, -1), true},
+		{rt.NewPoint(6, 0, -5), rt.NewVector(0, 0, 1), true},
+		{rt.NewPoint(8, 1, 3.5), rt.NewVector(0, 0, 1), true},
+		{rt.NewPoint(9, -1, -8), rt.NewVector(2, 4, 6), false},
+		{rt.NewPoint(8, 3, -4), rt.NewVector(6, 2, 4), false},
+		{rt.NewPoint(9, -1, -2), rt.NewVector(4, 6, 2), false},
+		{rt.NewPoint(4, 0, 9), rt.NewVector(0, 0, -1), false},
+		{rt.NewPoint(8, 6, -1), rt.NewVector(0, -1, 0), false},
+		{rt.NewPoint(12, 5, 4), rt.NewVector(-1, 0, 0), false},
+	}
+
+	for _, example := range examples {
+		r := rt.NewRay(example.origin, example.direction.Norm())
+
+		if box.Intersects(r) != example.result {
+			t.Errorf("Error: %v %v", example.origin, example.direction)
+		}
+	}
+}
+
+func TestGroupDivide(t *testing.T) {
+	/* Scenario: Subdividing a group partitions its children
+	   Given s1 ← sphere() with:
+	       | transform | translation(-2, -2, 0) |
+	     And s2 ← sphere() with:
+	       | transform | translation(-2, 2, 0) |
+	     And s3 ← sphere() with:
+	       | transform | scaling(4, 4, 4) |
+	     And g ← group() of [s1, s2, s3]
+	   When divide(g, 1)
+	   Then g is a tree of groups with each sphere in a leaf */
+	s1 := rt.NewSphere()
+	s1.SetTransform(rt.Translation(-2, -2, 0))
+	s2 := rt.NewSphere()
+	s2.SetTransform(rt.Translation(-2, 2, 0))
+	s3 := rt.NewSphere()
+	s3.SetTransform(rt.Scaling(4, 4, 4))
+	g := rt.NewGroup()
+	g.AddChild(s1, s2, s3)
+
+	g.Divide(1)
+
+	if len(g.Children) != 2 {
+		t.Fatalf("Error: %v", g.Children)
+	}
+
+	left := g.Children[0].(*rt.Group)
+	if len(left.Children) != 1 || left.Children[0] != s1 {
+		t.Errorf("Error: %v", left.Children)
+	}
+
+	right := g.Children[1].(*rt.Group)
+	if len(right.Children) != 2 {
+		t.Fatalf("Error: %v", right.Children)
+	}
+
+	for idx, expected := range []rt.Shape{s3, s2} {
+		leaf := right.Children[idx].(*rt.Group)
+		if len(leaf.Children) != 1 || leaf.Children[0] != expected {
+			t.Errorf("Error: %v", leaf.Children)
+		}
+	}
+}
+
+func TestGroupDivideKeepsUnboundedChildren(t *testing.T) {
+	/* Scenario: Subdividing a group leaves infinite shapes in place */
+	p := rt.NewPlane()
+	s1 := rt.NewSphere()
+	s1.SetTransform(rt.Translation(-2, 0, 0))
+	s2 := rt.NewSphere()
+	s2.SetTransform(rt.Translation(2, 0, 0))
+	g := rt.NewGroup()
+	g.AddChild(p, s1, s2)
+
+	g.Divide(2)
+
+	if len(g.Children) != 3 || g.Children[0] != p {
+		t.Fatalf("Error: %v", g.Children)
+	}
+
+	r := rt.NewRay(rt.NewPoint(2, 0, -5), rt.NewVector(0, 0, 1))
+	xs := g.Intersect(r)
+
+	if len(xs) != 2 || xs[0].Object != s2 {
+		t.Errorf("Error: %v", xs)
+	}
+}
+
+func TestGroupDivideSameIntersections(t *testing.T) {
+	/* Scenario: A divided mesh yields the same hits as the flat mesh */
+	flat := meshGroup(t, 20)
+	divided := meshGroup(t, 20)
+	divided.Divide(4)
+
+	for y := -1.0; y <= 1; y += 0.25 {
+		for x := -1.0; x <= 1; x += 0.25 {
+			r := rt.NewRay(rt.NewPoint(x, y, -5), rt.NewVector(0, 0, 1))
+
+			hit1 := flat.Intersect(r).Hit()
+			hit2 := divided.Intersect(r).Hit()
+
+			if (hit1 == nil) != (hit2 == nil) || (hit1 != nil && hit1.T != hit2.T) {
+				t.Errorf("Error: %v %v %v %v", x, y, hit1, hit2)
+			}
+		}
+	}
+}
+
+// meshObj returns an OBJ description of a unit sphere tessellated into
+// 2 * segments * segments triangles.
+func meshObj(segments int) string {
+	var obj strings.Builder
+
+	for stack := 0; stack <= segments; stack++ {
+		phi := math.Pi * float64(stack) / float64(segments)
+		for slice := 0; slice < segments; slice++ {
+			theta := 2 * math.Pi * float64(slice) / float64(segments)
+			fmt.Fprintf(&obj, "v %f %f %f\n", math.Sin(phi)*math.Cos(theta), math.Cos(phi), math.Sin(phi)*math.Sin(theta))
+		}
+	}
+
+	for stack := 0; stack < segments; stack++ {
+		for slice := 0; slice < segments; slice++ {
+			a := stack*segments + slice + 1
+			b := stack*segments + (slice+1)%segments + 1
+			c := a + segments
+			d := b + segments
+			fmt.Fprintf(&obj, "f %d %d %d\nf %d %d %d\n", a, b, d, a, d, c)
+		}
+	}
+
+	return obj.String()
+}
+
+func meshGroup(tb testing.TB, segments int) *rt.Group {
+	parser, err := rt.ParseObjFile(strings.NewReader(meshObj(segments)))
+	if err != nil {
+		tb.Fatalf("Error: %v", err)
+	}
+	return parser.ToGroup()
+}
+
+func BenchmarkRenderMeshBVH(b *testing.B) {
+	// 2 * 224 * 224 = 100352 triangles
+	mesh := meshGroup(b, 224)
+	mesh.Divide(8)
+
+	w := rt.NewWorld()
+	w.AddObject(mesh)
+	w.AddLight(rt.NewPointLight(rt.NewPoint(-10, 10, -10), &rt.Color{1, 1, 1}))
+
+	c := rt.NewCamera(100, 100, math.Pi/3)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(0, 0, -4), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Render(w)
+	}
+}
+
+func BenchmarkDivideMesh(b *testing.B) {
+	src := meshObj(224)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parser, err := rt.ParseObjFile(strings.NewReader(src))
+		if err != nil {
+			b.Fatalf("Error: %v", err)
+		}
+		parser.ToGroup().Divide(8)
+	}
+}
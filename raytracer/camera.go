@@ -0,0 +1,63 @@
+package raytracer
+
+import "math"
+
+type Camera struct {
+	HSize       int
+	VSize       int
+	FieldOfView float64
+	Transform   Matrix
+	HalfWidth   float64
+	HalfHeight  float64
+	PixelSize   float64
+	inverse     Matrix
+}
+
+func NewCamera(hsize, vsize int, fieldOfView float64) *Camera {
+	c := &Camera{
+		HSize:       hsize,
+		VSize:       vsize,
+		FieldOfView: fieldOfView,
+	}
+	c.SetTransform(Identity())
+
+	halfView := math.Tan(fieldOfView / 2)
+	aspect := float64(hsize) / float64(vsize)
+
+	if aspect >= 1 {
+		c.HalfWidth = halfView
+		c.HalfHeight = halfView / aspect
+	} else {
+		c.HalfWidth = halfView * aspect
+		c.HalfHeight = halfView
+	}
+
+	c.PixelSize = (c.HalfWidth * 2) / float64(hsize)
+
+	return c
+}
+
+func (c *Camera) SetTransform(transform Matrix) {
+	c.Transform = transform
+	c.inverse = transform.Inv()
+}
+
+func (c *Camera) RayForPixel(x, y int) Ray {
+	return c.RayForPixelOffset(x, y, 0.5, 0.5)
+}
+
+// RayForPixelOffset returns a ray through the point at (dx, dy) within the
+// pixel, where (0.5, 0.5) is its center.
+func (c *Camera) RayForPixelOffset(x, y int, dx, dy float64) Ray {
+	xOffset := (float64(x) + dx) * c.PixelSize
+	yOffset := (float64(y) + dy) * c.PixelSize
+
+	worldX := c.HalfWidth - xOffset
+	worldY := c.HalfHeight - yOffset
+
+	pixel := c.inverse.MulT(NewPoint(worldX, worldY, -1))
+	origin := c.inverse.MulT(NewPoint(0, 0, 0))
+	direction := pixel.Sub(origin).Norm()
+
+	return NewRay(origin, direction)
+}
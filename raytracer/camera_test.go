@@ -0,0 +1,161 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestNewCamera(t *testing.T) {
+	/* Scenario: Constructing a camera
+	   Given hsize ← 160
+	     And vsize ← 120
+	     And field_of_view ← π/2
+	   When c ← camera(hsize, vsize, field_of_view)
+	   Then c.hsize = 160
+	     And c.vsize = 120
+	     And c.field_of_view = π/2
+	     And c.transform = identity_matrix */
+	c := rt.NewCamera(160, 120, math.Pi/2)
+
+	if c.HSize != 160 {
+		t.Errorf("Error: %v", c.HSize)
+	}
+
+	if c.VSize != 120 {
+		t.Errorf("Error: %v", c.VSize)
+	}
+
+	if c.FieldOfView != math.Pi/2 {
+		t.Errorf("Error: %v", c.FieldOfView)
+	}
+
+	if !c.Transform.Equals(rt.Identity()) {
+		t.Errorf("Error: %v", c.Transform)
+	}
+}
+
+func TestCameraPixelSizeHorizontal(t *testing.T) {
+	/* Scenario: The pixel size for a horizontal canvas
+	   Given c ← camera(200, 125, π/2)
+	   Then c.pixel_size = 0.01 */
+	c := rt.NewCamera(200, 125, math.Pi/2)
+
+	if math.Abs(c.PixelSize-0.01) > 0.00001 {
+		t.Errorf("Error: %v", c.PixelSize)
+	}
+}
+
+func TestCameraPixelSizeVertical(t *testing.T) {
+	/* Scenario: The pixel size for a vertical canvas
+	   Given c ← camera(125, 200, π/2)
+	   Then c.pixel_size = 0.01 */
+	c := rt.NewCamera(125, 200, math.Pi/2)
+
+	if math.Abs(c.PixelSize-0.01) > 0.00001 {
+		t.Errorf("Error: %v", c.PixelSize)
+	}
+}
+
+func TestRayForPixelCenter(t *testing.T) {
+	/* Scenario: Constructing a ray through the center of the canvas
+	   Given c ← camera(201, 101, π/2)
+	   When r ← ray_for_pixel(c, 100, 50)
+	   Then r.origin = point(0, 0, 0)
+	     And r.direction = vector(0, 0, -1) */
+	c := rt.NewCamera(201, 101, math.Pi/2)
+
+	r := c.RayForPixel(100, 50)
+
+	if !r.Origin.Equals(rt.NewPoint(0, 0, 0)) {
+		t.Errorf("Error: %v", r.Origin)
+	}
+
+	if !r.Direction.Equals(rt.NewVector(0, 0, -1)) {
+		t.Errorf("Error: %v", r.Direction)
+	}
+}
+
+func TestRayForPixelCorner(t *testing.T) {
+	/* Scenario: Constructing a ray through a corner of the canvas
+	   Given c ← camera(201, 101, π/2)
+	   When r ← ray_for_pixel(c, 0, 0)
+	   Then r.origin = point(0, 0, 0)
+	     And r.direction = vector(0.66519, 0.33259, -0.66851) */
+	c := rt.NewCamera(201, 101, math.Pi/2)
+
+	r := c.RayForPixel(0, 0)
+
+	if !r.Origin.Equals(rt.NewPoint(0, 0, 0)) {
+		t.Errorf("Error: %v", r.Origin)
+	}
+
+	if !r.Direction.Equals(rt.NewVector(0.66519, 0.33259, -0.66851)) {
+		t.Errorf("Error: %v", r.Direction)
+	}
+}
+
+func TestRayForPixelTransformedCamera(t *testing.T) {
+	/* Scenario: Constructing a ray when the camera is transformed
+	   Given c ← camera(201, 101, π/2)
+	   When c.transform ← rotation_y(π/4) * translation(0, -2, 5)
+	     And r ← ray_for_pixel(c, 100, 50)
+	   Then r.origin = point(0, 2, -5)
+	     And r.direction = vector(√2/2, 0, -√2/2) */
+	c := rt.NewCamera(201, 101, math.Pi/2)
+
+	c.SetTransform(rt.RotationY(math.Pi / 4).Mul(rt.Translation(0, -2, 5)))
+	r := c.RayForPixel(100, 50)
+
+	if !r.Origin.Equals(rt.NewPoint(0, 2, -5)) {
+		t.Errorf("Error: %v", r.Origin)
+	}
+
+	if !r.Direction.Equals(rt.NewVector(math.Sqrt(2)/2, 0, -math.Sqrt(2)/2)) {
+		t.Errorf("Error: %v", r.Direction)
+	}
+}
+
+func TestRender(t *testing.T) {
+	/* Scenario: Rendering a world with a camera
+	   Given w ← default_world()
+	     And c ← camera(11, 11, π/2)
+	     And from ← point(0, 0, -5)
+	     And to ← point(0, 0, 0)
+	     And up ← vector(0, 1, 0)
+	     And c.transform ← view_transform(from, to, up)
+	   When image ← render(c, w)
+	   Then pixel_at(image, 5, 5) = color(0.38066, 0.47583, 0.2855) */
+	w := rt.DefaultWorld()
+	c := rt.NewCamera(11, 11, math.Pi/2)
+	from := rt.NewPoint(0, 0, -5)
+	to := rt.NewPoint(0, 0, 0)
+	up := rt.NewVector(0, 1, 0)
+	c.SetTransform(rt.ViewTransform(from, to, up))
+
+	image := c.Render(w)
+
+	if *image.GetAt(5, 5).RGBA() != *(&rt.Color{0.38066, 0.47583, 0.2855}).RGBA() {
+		t.Errorf("Error: %v", image.GetAt(5, 5))
+	}
+}
+
+func TestRayForPixelOffset(t *testing.T) {
+	/* Scenario: Constructing a ray through the corner of a pixel
+	   Given c ← camera(201, 101, π/2)
+	   When r ← ray_for_pixel_offset(c, 0, 0, 0, 0)
+	   Then r.direction points at the top left corner of the canvas */
+	c := rt.NewCamera(201, 101, math.Pi/2)
+
+	r := c.RayForPixelOffset(0, 0, 0, 0)
+
+	expected := rt.NewVector(c.HalfWidth, c.HalfHeight, -1).Norm()
+	if !r.Direction.Equals(expected) {
+		t.Errorf("Error: %v", r.Direction)
+	}
+
+	if !c.RayForPixelOffset(100, 50, 0.5, 0.5).Direction.Equals(c.RayForPixel(100, 50).Direction) {
+		t.Errorf("Error: %v", c.RayForPixelOffset(100, 50, 0.5, 0.5))
+	}
+}
@@ -1,39 +1,252 @@
 package raytracer
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"image"
+	"image/gif"
+	"image/jpeg"
 	"image/png"
-	"log"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// Canvas is a floating point framebuffer. It keeps the colors as traced,
+// including radiance above 1, and only turns them into displayable 8 bit
+// pixels when encoded, as configured by Display.
 type Canvas struct {
-	image *image.RGBA
+	Width   int
+	Height  int
+	Display Display
+
+	pixels []Color
 }
 
 func NewCanvas(width, height int) *Canvas {
-	return &Canvas{image.NewRGBA(image.Rect(0, 0, width, height))}
+	return &Canvas{Width: width, Height: height, pixels: make([]Color, width*height)}
 }
 
+// maxDecodedValues limits the width*height*channels of decoded images, so a
+// corrupt header can't make a decoder allocate gigabytes before it finds
+// out that the pixel data is missing.
+const maxDecodedValues = 1 << 27
+
+// validDecodedSize reports whether an image of width by height pixels with
+// channels values each is neither empty nor too large to decode.
+func validDecodedSize(width, height int64, channels int) bool {
+	return width > 0 && height > 0 && channels > 0 &&
+		width <= maxDecodedValues/height/int64(channels)
+}
+
+func (c *Canvas) inBounds(x, y int) bool {
+	return x >= 0 && x < c.Width && y >= 0 && y < c.Height
+}
+
+// SetAt stores color at (x, y). Points outside the canvas are ignored.
 func (c *Canvas) SetAt(x, y int, color *Color) {
-	c.image.Set(x, y, color.RGBA())
+	if c.inBounds(x, y) {
+		c.pixels[y*c.Width+x] = *color
+	}
 }
 
+// GetAt returns the color stored at (x, y), or black outside the canvas.
 func (c *Canvas) GetAt(x, y int) *Color {
-	r, g, b, _ := c.image.At(x, y).RGBA()
-	return &Color{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+	if !c.inBounds(x, y) {
+		return &Color{}
+	}
+
+	color := c.pixels[y*c.Width+x]
+	return &color
+}
+
+// Image converts the canvas to 8 bit pixels using its Display settings.
+func (c *Canvas) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, c.Width, c.Height))
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			img.SetRGBA(x, y, *c.Display.Apply(&c.pixels[y*c.Width+x]).RGBA())
+		}
+	}
+
+	return img
+}
+
+// FormatFromFilename returns the image format matching the extension of
+// filename, like "png" for "ball.png".
+func FormatFromFilename(filename string) string {
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	if format == "jpg" {
+		format = "jpeg"
+	}
+
+	return format
+}
+
+// Encoder writes a canvas to w in one image format.
+type Encoder func(w io.Writer, c *Canvas) error
+
+// ErrUnsupportedFormat is returned, wrapped with the format's name, for
+// formats there is no encoder or decoder for.
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// canvasEncoders and canvasDecoders map format names to the functions
+// writing and reading them. The 8 bit formats go through Display, the high
+// dynamic range ones store the colors as they are.
+var canvasEncoders = map[string]Encoder{
+	"png": func(w io.Writer, c *Canvas) error {
+		return png.Encode(w, c.Image())
+	},
+	"jpeg": encodeJPEG,
+	"jpg":  encodeJPEG,
+	"gif": func(w io.Writer, c *Canvas) error {
+		return gif.Encode(w, c.Image(), nil)
+	},
+	"pfm": EncodePFM,
+	"hdr": EncodeHDR,
+	"exr": func(w io.Writer, c *Canvas) error {
+		return EncodeEXR(w, c, EXRZipCompression)
+	},
+	"exr-uncompressed": func(w io.Writer, c *Canvas) error {
+		return EncodeEXR(w, c, EXRNoCompression)
+	},
+	"ppm":       EncodePPM,
+	"ppm-plain": EncodePlainPPM,
+	"pgm":       EncodePGM,
+}
+
+var canvasDecoders = map[string]func(r io.Reader) (*Canvas, error){
+	"png":  decodeImage,
+	"jpeg": decodeImage,
+	"jpg":  decodeImage,
+	"gif":  decodeImage,
+	"pfm":  DecodePFM,
+	"hdr":  DecodeHDR,
+	"exr":  DecodeEXR,
+	"ppm":  DecodePPM,
+	"pgm":  DecodePPM,
+}
+
+func unsupportedFormat(format string) error {
+	return fmt.Errorf("%w %q", ErrUnsupportedFormat, format)
+}
+
+func EncoderByName(name string) (Encoder, error) {
+	encode, ok := canvasEncoders[name]
+	if !ok {
+		return nil, unsupportedFormat(name)
+	}
+
+	return encode, nil
+}
+
+// Formats returns the names of the formats canvases can be encoded in.
+func Formats() []string {
+	var formats []string
+	for format := range canvasEncoders {
+		if format != "jpg" {
+			formats = append(formats, format)
+		}
+	}
+	sort.Strings(formats)
+
+	return formats
 }
 
-func (c *Canvas) Save(filename string) {
+func encodeJPEG(w io.Writer, c *Canvas) error {
+	return jpeg.Encode(w, c.Image(), &jpeg.Options{Quality: 95})
+}
+
+// decodeImage reads an 8 bit image and undoes the sRGB transfer curve, the
+// inverse of the default Display.
+func decodeImage(r io.Reader) (*Canvas, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	c := NewCanvas(bounds.Dx(), bounds.Dy())
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			c.SetAt(x, y, &Color{SRGBDecode(float64(r) / 0xffff), SRGBDecode(float64(g) / 0xffff), SRGBDecode(float64(b) / 0xffff)})
+		}
+	}
+
+	return c, nil
+}
+
+// Encode writes the canvas to w, a file, buffer or network connection, in
+// the named format. See Formats for the ones available.
+func (c *Canvas) Encode(w io.Writer, format string) error {
+	encode, err := EncoderByName(format)
+	if err != nil {
+		return err
+	}
+
+	return encode(w, c)
+}
+
+// Save writes the canvas to filename in the format matching its extension.
+func (c *Canvas) Save(filename string) error {
+	return c.SaveAs(filename, FormatFromFilename(filename))
+}
+
+// SaveAs writes the canvas to filename in the named format. When writing
+// fails, for instance because the disk is full, an incomplete regular file is
+// removed.
+func (c *Canvas) SaveAs(filename, format string) error {
+	encode, err := EncoderByName(format)
+	if err != nil {
+		return err
+	}
+
 	f, err := os.Create(filename)
-	defer f.Close()
+	if err != nil {
+		return err
+	}
 
+	w := bufio.NewWriter(f)
+	err = encode(w, c)
+	if err == nil {
+		err = w.Flush()
+	}
+	info, statErr := f.Stat()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
-		log.Fatal(err)
+		if statErr == nil && info.Mode().IsRegular() {
+			os.Remove(filename)
+		}
+		return err
 	}
 
-	if err := png.Encode(f, c.image); err != nil {
-		log.Fatal(err)
+	return nil
+}
+
+// DecodeCanvas reads a canvas in the named format from r.
+func DecodeCanvas(r io.Reader, format string) (*Canvas, error) {
+	decode, ok := canvasDecoders[format]
+	if !ok {
+		return nil, unsupportedFormat(format)
+	}
+
+	return decode(r)
+}
+
+// LoadCanvas reads a canvas from filename in the format matching its
+// extension.
+func LoadCanvas(filename string) (*Canvas, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
 	}
+	defer f.Close()
 
+	return DecodeCanvas(bufio.NewReader(f), FormatFromFilename(filename))
 }
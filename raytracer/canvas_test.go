@@ -0,0 +1,212 @@
+package raytracer_test
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestFormatFromFilename(t *testing.T) {
+	examples := []struct {
+		filename string
+		format   string
+	}{
+		{"ball.png", "png"},
+		{"out/ball.JPG", "jpeg"},
+		{"ball.jpeg", "jpeg"},
+		{"ball", ""},
+	}
+
+	for _, example := range examples {
+		if format := rt.FormatFromFilename(example.filename); format != example.format {
+			t.Errorf("Error: %v %v", example.filename, format)
+		}
+	}
+}
+
+func TestCanvasEncode(t *testing.T) {
+	c := rt.NewCanvas(3, 2)
+	c.SetAt(1, 1, &rt.Color{1, 0, 0})
+
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, "png"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if r, g, b, _ := img.At(1, 1).RGBA(); r != 0xffff || g != 0 || b != 0 {
+		t.Errorf("Error: %v %v %v", r, g, b)
+	}
+
+	if err := c.Encode(&buf, "tiff"); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+// failingWriter fails every write, like a full disk or a closed connection.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("no space left on device")
+}
+
+func TestCanvasEncodeFormats(t *testing.T) {
+	c := rt.NewCanvas(20, 10)
+	c.SetAt(3, 4, &rt.Color{1, 0.5, 0.25})
+
+	for _, format := range rt.Formats() {
+		var buf bytes.Buffer
+		if err := c.Encode(&buf, format); err != nil || buf.Len() == 0 {
+			t.Errorf("Error: %v %v", format, err)
+		}
+
+		if err := c.Encode(failingWriter{}, format); err == nil {
+			t.Errorf("Error: %v", format)
+		}
+	}
+}
+
+func TestCanvasEncodeGIF(t *testing.T) {
+	c := rt.NewCanvas(3, 2)
+	c.SetAt(1, 1, &rt.Color{1, 0, 0})
+
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, "gif"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	decoded, err := rt.DecodeCanvas(&buf, "gif")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if color := decoded.GetAt(1, 1); !color.Equals(&rt.Color{1, 0, 0}) {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestEncoderByName(t *testing.T) {
+	if _, err := rt.EncoderByName("png"); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if _, err := rt.EncoderByName("tiff"); !errors.Is(err, rt.ErrUnsupportedFormat) {
+		t.Errorf("Error: %v", err)
+	}
+
+	if _, err := rt.DecodeCanvas(&bytes.Buffer{}, "tiff"); !errors.Is(err, rt.ErrUnsupportedFormat) {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestCanvasSave(t *testing.T) {
+	dir := t.TempDir()
+
+	c := rt.NewCanvas(3, 2)
+
+	if err := c.Save(filepath.Join(dir, "canvas.jpg")); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if err := c.Save(filepath.Join(dir, "canvas.tiff")); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "canvas.tiff")); !os.IsNotExist(err) {
+		t.Errorf("Error: %v", err)
+	}
+
+	if err := c.Save(filepath.Join(dir, "missing", "canvas.png")); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	// PNG can't store an empty image, the file that failed is removed.
+	empty := filepath.Join(dir, "empty.png")
+	if err := rt.NewCanvas(0, 0).Save(empty); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if _, err := os.Stat(empty); !os.IsNotExist(err) {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestNewCanvas(t *testing.T) {
+	/* Scenario: Creating a canvas
+	   Given c ← canvas(10, 20)
+	   Then c.width = 10
+	     And c.height = 20
+	     And every pixel of c is color(0, 0, 0) */
+	c := rt.NewCanvas(10, 20)
+
+	if c.Width != 10 || c.Height != 20 {
+		t.Errorf("Error: %v %v", c.Width, c.Height)
+	}
+
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 10; x++ {
+			if !c.GetAt(x, y).Equals(&rt.Color{0, 0, 0}) {
+				t.Errorf("Error: %v %v", x, y)
+			}
+		}
+	}
+}
+
+func TestCanvasKeepsHighDynamicRange(t *testing.T) {
+	/* Scenario: Writing pixels to a canvas keeps colors brighter than white
+	   Given c ← canvas(10, 20)
+	     And bright ← color(2.5, 1, 0.125)
+	   When write_pixel(c, 2, 3, bright)
+	   Then pixel_at(c, 2, 3) = bright */
+	c := rt.NewCanvas(10, 20)
+	bright := &rt.Color{2.5, 1, 0.125}
+
+	c.SetAt(2, 3, bright)
+
+	if *c.GetAt(2, 3) != *bright {
+		t.Errorf("Error: %v", c.GetAt(2, 3))
+	}
+
+	// Writing outside the canvas is ignored.
+	c.SetAt(10, 0, bright)
+	c.SetAt(-1, 0, bright)
+	if !c.GetAt(10, 0).Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", c.GetAt(10, 0))
+	}
+}
+
+func TestCanvasImageDisplay(t *testing.T) {
+	c := rt.NewCanvas(2, 1)
+	c.SetAt(0, 0, &rt.Color{4, 0.5, 0})
+	c.SetAt(1, 0, &rt.Color{0.18, 0.18, 0.18})
+
+	img := c.Image()
+	if rgba := img.RGBAAt(0, 0); rgba.R != 255 || rgba.G != 188 || rgba.B != 0 {
+		t.Errorf("Error: %v", rgba)
+	}
+
+	c.Display.Transfer = rt.LinearTransfer
+
+	img = c.Image()
+	if rgba := img.RGBAAt(0, 0); rgba.R != 255 || rgba.G != 128 || rgba.B != 0 {
+		t.Errorf("Error: %v", rgba)
+	}
+
+	c.Display = rt.Display{Exposure: -2, ToneMapper: rt.ACESToneMap}
+
+	img = c.Image()
+	if rgba := img.RGBAAt(0, 0); rgba.R != 232 || rgba.G < 100 || rgba.G > 130 {
+		t.Errorf("Error: %v", rgba)
+	}
+	if rgba := img.RGBAAt(1, 0); rgba.R != rgba.G || rgba.R < 50 || rgba.R > 70 {
+		t.Errorf("Error: %v", rgba)
+	}
+}
@@ -41,11 +41,34 @@ func (c *Color) Prod(b *Color) *Color {
 	return &Color{c.R * b.R, c.G * b.G, c.B * b.B}
 }
 
+// RGBA converts c to 8 bits per channel, clamping channels outside [0, 1]
+// like bright highlights or the negative lobes of a filter.
 func (c *Color) RGBA() *color.RGBA {
 	return &color.RGBA{
-		R: uint8(c.R * 255),
-		G: uint8(c.G * 255),
-		B: uint8(c.B * 255),
+		R: to8Bit(c.R),
+		G: to8Bit(c.G),
+		B: to8Bit(c.B),
 		A: 255,
 	}
 }
+
+// to8Bit scales a displayed channel to 0-255, rounding to the nearest value.
+// All 8 bit encoders go through it, so they agree on every pixel.
+func to8Bit(x float64) uint8 {
+	return uint8(math.Round(clamp01(x) * 255))
+}
+
+// clamp01 limits x to [0, 1], mapping NaN to 0.
+func clamp01(x float64) float64 {
+	if math.IsNaN(x) {
+		return 0
+	}
+
+	return math.Max(0, math.Min(1, x))
+}
+
+// luminance returns the brightness of c as perceived, with the Rec. 709
+// weights.
+func luminance(c *Color) float64 {
+	return 0.2126*c.R + 0.7152*c.G + 0.0722*c.B
+}
@@ -81,3 +81,16 @@ func TestProdColors(t *testing.T) {
 		t.Errorf("Error: %v", result)
 	}
 }
+
+func TestColorRGBAClamps(t *testing.T) {
+	/* Scenario: Converting a color outside [0, 1] to 8 bits clamps it
+	   Given c ← color(1.5, -0.5, 0.5)
+	   Then rgba(c) = (255, 0, 128, 255) */
+	c := &rt.Color{1.5, -0.5, 0.5}
+
+	rgba := c.RGBA()
+
+	if rgba.R != 255 || rgba.G != 0 || rgba.B != 128 || rgba.A != 255 {
+		t.Errorf("Error: %v", rgba)
+	}
+}
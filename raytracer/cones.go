@@ -0,0 +1,97 @@
+package raytracer
+
+import "math"
+
+type Cone struct {
+	shape
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func NewCone() *Cone {
+	c := &Cone{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	c.shape = newShape(c)
+	return c
+}
+
+func (c *Cone) intersectCaps(r Ray, xs Intersections) Intersections {
+	if !c.Closed || math.Abs(r.Direction.Y) < epsilon {
+		return xs
+	}
+
+	t := (c.Minimum - r.Origin.Y) / r.Direction.Y
+	if checkCap(r, t, math.Abs(c.Minimum)) {
+		xs = append(xs, NewIntersection(t, c))
+	}
+
+	t = (c.Maximum - r.Origin.Y) / r.Direction.Y
+	if checkCap(r, t, math.Abs(c.Maximum)) {
+		xs = append(xs, NewIntersection(t, c))
+	}
+
+	return xs
+}
+
+func (c *Cone) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	a := math.Pow(r.Direction.X, 2) - math.Pow(r.Direction.Y, 2) + math.Pow(r.Direction.Z, 2)
+	b := 2*r.Origin.X*r.Direction.X - 2*r.Origin.Y*r.Direction.Y + 2*r.Origin.Z*r.Direction.Z
+	c2 := math.Pow(r.Origin.X, 2) - math.Pow(r.Origin.Y, 2) + math.Pow(r.Origin.Z, 2)
+
+	if math.Abs(a) < epsilon {
+		if math.Abs(b) >= epsilon {
+			t := -c2 / (2 * b)
+			y := r.Origin.Y + t*r.Direction.Y
+			if c.Minimum < y && y < c.Maximum {
+				xs = append(xs, NewIntersection(t, c))
+			}
+		}
+		return c.intersectCaps(r, xs)
+	}
+
+	disc := math.Pow(b, 2) - 4*a*c2
+	if disc < 0 {
+		return xs
+	}
+
+	t0 := (-b - math.Sqrt(disc)) / (2 * a)
+	t1 := (-b + math.Sqrt(disc)) / (2 * a)
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+
+	y0 := r.Origin.Y + t0*r.Direction.Y
+	if c.Minimum < y0 && y0 < c.Maximum {
+		xs = append(xs, NewIntersection(t0, c))
+	}
+
+	y1 := r.Origin.Y + t1*r.Direction.Y
+	if c.Minimum < y1 && y1 < c.Maximum {
+		xs = append(xs, NewIntersection(t1, c))
+	}
+
+	return c.intersectCaps(r, xs)
+}
+
+func (c *Cone) LocalNormalAt(p Tuple) Tuple {
+	dist := math.Pow(p.X, 2) + math.Pow(p.Z, 2)
+
+	if dist < math.Pow(c.Maximum, 2) && p.Y >= c.Maximum-epsilon {
+		return NewVector(0, 1, 0)
+	} else if dist < math.Pow(c.Minimum, 2) && p.Y <= c.Minimum+epsilon {
+		return NewVector(0, -1, 0)
+	}
+
+	y := math.Sqrt(dist)
+	if p.Y > 0 {
+		y = -y
+	}
+
+	return NewVector(p.X, y, p.Z)
+}
+
+func (c *Cone) Bounds() *BoundingBox {
+	limit := math.Max(math.Abs(c.Minimum), math.Abs(c.Maximum))
+
+	return NewBoundingBox(NewPoint(-limit, c.Minimum, -limit), NewPoint(limit, c.Maximum, limit))
+}
@@ -0,0 +1,122 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestConeIntersect(t *testing.T) {
+	/* Scenario Outline: Intersecting a cone with a ray
+	   Given shape ← cone()
+	     And direction ← normalize(<direction>)
+	     And r ← ray(<origin>, direction)
+	   When xs ← local_intersect(shape, r)
+	   Then xs.count = 2
+	     And xs[0].t = <t0>
+	     And xs[1].t = <t1> */
+	examples := []struct {
+		origin, direction rt.Tuple
+		t0, t1            float64
+	}{
+		{rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1), 5, 5},
+		{rt.NewPoint(0, 0, -5), rt.NewVector(1, 1, 1), 8.66025, 8.66025},
+		{rt.NewPoint(1, 1, -5), rt.NewVector(-0.5, -1, 1), 4.55006, 49.44994},
+	}
+
+	shape := rt.NewCone()
+	for _, example := range examples {
+		r := rt.NewRay(example.origin, example.direction.Norm())
+
+		xs := shape.LocalIntersect(r)
+
+		if len(xs) != 2 {
+			t.Errorf("Error: %v", len(xs))
+			continue
+		}
+
+		if math.Abs(xs[0].T-example.t0) > 0.0001 || math.Abs(xs[1].T-example.t1) > 0.0001 {
+			t.Errorf("Error: %v %v", xs[0].T, xs[1].T)
+		}
+	}
+}
+
+func TestConeIntersectParallel(t *testing.T) {
+	/* Scenario: Intersecting a cone with a ray parallel to one of its halves
+	   Given shape ← cone()
+	     And direction ← normalize(vector(0, 1, 1))
+	     And r ← ray(point(0, 0, -1), direction)
+	   When xs ← local_intersect(shape, r)
+	   Then xs.count = 1
+	     And xs[0].t = 0.35355 */
+	shape := rt.NewCone()
+	r := rt.NewRay(rt.NewPoint(0, 0, -1), rt.NewVector(0, 1, 1).Norm())
+
+	xs := shape.LocalIntersect(r)
+
+	if len(xs) != 1 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	if math.Abs(xs[0].T-0.35355) > 0.00001 {
+		t.Errorf("Error: %v", xs[0].T)
+	}
+}
+
+func TestConeCaps(t *testing.T) {
+	/* Scenario Outline: Intersecting a cone's end caps
+	   Given shape ← cone()
+	     And shape.minimum ← -0.5
+	     And shape.maximum ← 0.5
+	     And shape.closed ← true
+	     And direction ← normalize(<direction>)
+	     And r ← ray(<origin>, direction)
+	   When xs ← local_intersect(shape, r)
+	   Then xs.count = <count> */
+	examples := []struct {
+		origin, direction rt.Tuple
+		count             int
+	}{
+		{rt.NewPoint(0, 0, -5), rt.NewVector(0, 1, 0), 0},
+		{rt.NewPoint(0, 0, -0.25), rt.NewVector(0, 1, 1), 2},
+		{rt.NewPoint(0, 0, -0.25), rt.NewVector(0, 1, 0), 4},
+	}
+
+	shape := rt.NewCone()
+	shape.Minimum = -0.5
+	shape.Maximum = 0.5
+	shape.Closed = true
+	for _, example := range examples {
+		r := rt.NewRay(example.origin, example.direction.Norm())
+
+		xs := shape.LocalIntersect(r)
+
+		if len(xs) != example.count {
+			t.Errorf("Error: %v", len(xs))
+		}
+	}
+}
+
+func TestConeNormal(t *testing.T) {
+	/* Scenario Outline: Computing the normal vector on a cone
+	   Given shape ← cone()
+	   When n ← local_normal_at(shape, <point>)
+	   Then n = <normal> */
+	examples := []struct {
+		point, normal rt.Tuple
+	}{
+		{rt.NewPoint(0, 0, 0), rt.NewVector(0, 0, 0)},
+		{rt.NewPoint(1, 1, 1), rt.NewVector(1, -math.Sqrt(2), 1)},
+		{rt.NewPoint(-1, -1, 0), rt.NewVector(-1, 1, 0)},
+	}
+
+	shape := rt.NewCone()
+	for _, example := range examples {
+		n := shape.LocalNormalAt(example.point)
+
+		if !n.Equals(example.normal) {
+			t.Errorf("Error: %v", n)
+		}
+	}
+}
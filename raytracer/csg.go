@@ -0,0 +1,116 @@
+package raytracer
+
+type CSGOperation int
+
+const (
+	CSGUnion CSGOperation = iota
+	CSGIntersection
+	CSGDifference
+)
+
+// CSG combines two shapes with a boolean operation, keeping only those
+// intersections that lie on the surface of the combined solid.
+type CSG struct {
+	shape
+	Operation   CSGOperation
+	Left, Right Shape
+
+	bounds *BoundingBox
+}
+
+func NewCSG(operation CSGOperation, left, right Shape) *CSG {
+	c := &CSG{Operation: operation, Left: left, Right: right}
+	c.shape = newShape(c)
+	left.SetParent(c)
+	right.SetParent(c)
+	c.updateBounds()
+	return c
+}
+
+// updateBounds recomputes the bounds of c and its ancestors, like
+// Group.updateBounds.
+func (c *CSG) updateBounds() {
+	box := NewEmptyBoundingBox()
+	box.AddBox(c.Left.ParentSpaceBounds())
+	box.AddBox(c.Right.ParentSpaceBounds())
+	c.bounds = box
+
+	if c.Parent != nil {
+		updateParentBounds(c.Parent)
+	}
+}
+
+func (c *CSG) Includes(other Shape) bool {
+	return c.Left.Includes(other) || c.Right.Includes(other)
+}
+
+// IntersectionAllowed reports whether a hit on the left or right operand is
+// part of the combined surface, given whether the ray is currently inside
+// the left and the right operand.
+func (c *CSG) IntersectionAllowed(leftHit, inLeft, inRight bool) bool {
+	switch c.Operation {
+	case CSGUnion:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case CSGIntersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case CSGDifference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+
+	return false
+}
+
+// FilterIntersections walks the sorted intersections xs, tracking whether
+// the ray is inside either operand, and returns the allowed ones.
+func (c *CSG) FilterIntersections(xs Intersections) Intersections {
+	return c.appendAllowed(NewIntersections(), xs)
+}
+
+// appendAllowed appends the allowed intersections of xs to dst. dst may
+// share the storage of xs as long as it ends where xs starts, which filters
+// xs in place.
+func (c *CSG) appendAllowed(dst, xs Intersections) Intersections {
+	inLeft, inRight := false, false
+
+	for _, intersection := range xs {
+		leftHit := c.Left.Includes(intersection.Object)
+
+		if c.IntersectionAllowed(leftHit, inLeft, inRight) {
+			dst = append(dst, intersection)
+		}
+
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+
+	return dst
+}
+
+func (c *CSG) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	if !c.Bounds().Intersects(r) {
+		return xs
+	}
+
+	start := len(xs)
+	xs = c.Left.AppendIntersections(xs, r)
+	xs = c.Right.AppendIntersections(xs, r)
+	xs[start:].Sort()
+
+	return c.appendAllowed(xs[:start], xs[start:])
+}
+
+func (c *CSG) LocalNormalAt(p Tuple) Tuple {
+	panic("CSG has no normal, normals are computed on its operands")
+}
+
+func (c *CSG) Bounds() *BoundingBox {
+	return c.bounds
+}
+
+func (c *CSG) Divide(threshold int) {
+	c.Left.Divide(threshold)
+	c.Right.Divide(threshold)
+}
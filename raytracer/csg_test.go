@@ -0,0 +1,260 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestNewCSG(t *testing.T) {
+	/* Scenario: CSG is created with an operation and two shapes
+	   Given s1 ← sphere()
+	     And s2 ← cube()
+	   When c ← csg("union", s1, s2)
+	   Then c.operation = "union"
+	     And c.left = s1
+	     And c.right = s2
+	     And s1.parent = c
+	     And s2.parent = c */
+	s1 := rt.NewSphere()
+	s2 := rt.NewCube()
+	c := rt.NewCSG(rt.CSGUnion, s1, s2)
+
+	if c.Operation != rt.CSGUnion || c.Left != s1 || c.Right != s2 {
+		t.Errorf("Error: %v", c)
+	}
+
+	if s1.GetParent() != c || s2.GetParent() != c {
+		t.Errorf("Error: %v %v", s1.GetParent(), s2.GetParent())
+	}
+}
+
+func TestCSGIntersectionAllowed(t *testing.T) {
+	/* Scenario Outline: Evaluating the rule for a CSG operation
+	   When result ← intersection_allowed("<op>", <lhit>, <inl>, <inr>)
+	   Then result = <result> */
+	examples := []struct {
+		op                   rt.CSGOperation
+		lhit, inl, inr, want bool
+	}{
+		{rt.CSGUnion, true, true, true, false},
+		{rt.CSGUnion, true, true, false, true},
+		{rt.CSGUnion, true, false, true, false},
+		{rt.CSGUnion, true, false, false, true},
+		{rt.CSGUnion, false, true, true, false},
+		{rt.CSGUnion, false, true, false, false},
+		{rt.CSGUnion, false, false, true, true},
+		{rt.CSGUnion, false, false, false, true},
+		{rt.CSGIntersection, true, true, true, true},
+		{rt.CSGIntersection, true, true, false, false},
+		{rt.CSGIntersection, true, false, true, true},
+		{rt.CSGIntersection, true, false, false, false},
+		{rt.CSGIntersection, false, true, true, true},
+		{rt.CSGIntersection, false, true, false, true},
+		{rt.CSGIntersection, false, false, true, false},
+		{rt.CSGIntersection, false, false, false, false},
+		{rt.CSGDifference, true, true, true, false},
+		{rt.CSGDifference, true, true, false, true},
+		{rt.CSGDifference, true, false, true, false},
+		{rt.CSGDifference, true, false, false, true},
+		{rt.CSGDifference, false, true, true, true},
+		{rt.CSGDifference, false, true, false, true},
+		{rt.CSGDifference, false, false, true, false},
+		{rt.CSGDifference, false, false, false, false},
+	}
+
+	for _, example := range examples {
+		c := rt.NewCSG(example.op, rt.NewSphere(), rt.NewCube())
+
+		if c.IntersectionAllowed(example.lhit, example.inl, example.inr) != example.want {
+			t.Errorf("Error: %v", example)
+		}
+	}
+}
+
+func TestCSGFilterIntersections(t *testing.T) {
+	/* Scenario Outline: Filtering a list of intersections
+	   Given s1 ← sphere()
+	     And s2 ← cube()
+	     And c ← csg("<operation>", s1, s2)
+	     And xs ← intersections(1:s1, 2:s2, 3:s1, 4:s2)
+	   When result ← filter_intersections(c, xs)
+	   Then result.count = 2
+	     And result[0] = xs[<x0>]
+	     And result[1] = xs[<x1>] */
+	examples := []struct {
+		op     rt.CSGOperation
+		x0, x1 int
+	}{
+		{rt.CSGUnion, 0, 3},
+		{rt.CSGIntersection, 1, 2},
+		{rt.CSGDifference, 0, 1},
+	}
+
+	for _, example := range examples {
+		s1 := rt.NewSphere()
+		s2 := rt.NewCube()
+		c := rt.NewCSG(example.op, s1, s2)
+		xs := rt.NewIntersections(
+			rt.NewIntersection(1, s1),
+			rt.NewIntersection(2, s2),
+			rt.NewIntersection(3, s1),
+			rt.NewIntersection(4, s2),
+		)
+
+		result := c.FilterIntersections(xs)
+
+		if len(result) != 2 || result[0] != xs[example.x0] || result[1] != xs[example.x1] {
+			t.Errorf("Error: %v %v", example.op, result)
+		}
+	}
+}
+
+func TestCSGRayMisses(t *testing.T) {
+	/* Scenario: A ray misses a CSG object
+	   Given c ← csg("union", sphere(), cube())
+	     And r ← ray(point(0, 2, -5), vector(0, 0, 1))
+	   When xs ← local_intersect(c, r)
+	   Then xs is empty */
+	c := rt.NewCSG(rt.CSGUnion, rt.NewSphere(), rt.NewCube())
+	r := rt.NewRay(rt.NewPoint(0, 2, -5), rt.NewVector(0, 0, 1))
+
+	xs := c.LocalIntersect(r)
+
+	if len(xs) != 0 {
+		t.Errorf("Error: %v", xs)
+	}
+}
+
+func TestCSGRayHits(t *testing.T) {
+	/* Scenario: A ray hits a CSG object
+	   Given s1 ← sphere()
+	     And s2 ← sphere()
+	     And set_transform(s2, translation(0, 0, 0.5))
+	     And c ← csg("union", s1, s2)
+	     And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	   When xs ← local_intersect(c, r)
+	   Then xs.count = 2
+	     And xs[0].t = 4
+	     And xs[0].object = s1
+	     And xs[1].t = 6.5
+	     And xs[1].object = s2 */
+	s1 := rt.NewSphere()
+	s2 := rt.NewSphere()
+	s2.SetTransform(rt.Translation(0, 0, 0.5))
+	c := rt.NewCSG(rt.CSGUnion, s1, s2)
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+
+	xs := c.LocalIntersect(r)
+
+	if len(xs) != 2 {
+		t.Fatalf("Error: %v", xs)
+	}
+
+	if math.Abs(xs[0].T-4) > 0.00001 || xs[0].Object != s1 {
+		t.Errorf("Error: %v", xs[0])
+	}
+
+	if math.Abs(xs[1].T-6.5) > 0.00001 || xs[1].Object != s2 {
+		t.Errorf("Error: %v", xs[1])
+	}
+}
+
+func TestCSGIncludesGroupChildren(t *testing.T) {
+	/* Scenario: A CSG operand can be a group
+	   Given s1 ← sphere()
+	     And g ← group() containing s1
+	     And s2 ← cube()
+	     And c ← csg("difference", g, s2)
+	   Then c includes s1
+	     And s1 is the left operand's hit */
+	s1 := rt.NewSphere()
+	g := rt.NewGroup()
+	g.AddChild(s1)
+	s2 := rt.NewCube()
+	s2.SetTransform(rt.Translation(0, 0, 1))
+	c := rt.NewCSG(rt.CSGDifference, g, s2)
+
+	if !c.Includes(s1) || !c.Includes(s2) || c.Includes(rt.NewSphere()) {
+		t.Errorf("Error: %v", c)
+	}
+
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	xs := c.Intersect(r)
+
+	if len(xs) != 2 || math.Abs(xs[0].T-4) > 0.00001 || math.Abs(xs[1].T-5) > 0.00001 {
+		t.Fatalf("Error: %v", xs)
+	}
+
+	if xs[0].Object != s1 || xs[1].Object != s2 {
+		t.Errorf("Error: %v %v", xs[0].Object, xs[1].Object)
+	}
+}
+
+func TestCSGInsideGroup(t *testing.T) {
+	/* Scenario: A CSG object nested in a transformed group
+	   Given s ← sphere()
+	     And b ← cube() translated by (0, 0, 0.5)
+	     And c ← csg("intersection", s, b)
+	     And g ← group() containing c
+	     And set_transform(g, scaling(2, 2, 2))
+	     And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	   When xs ← intersect(g, r)
+	   Then xs.count = 2
+	     And xs[0].t = 4
+	     And xs[0].object = b
+	     And xs[1].t = 7
+	     And xs[1].object = s
+	     And normal_at(s, point(0, 0, 2)) = vector(0, 0, 1)
+	     And the bounds of g span from (-2, -2, -2) to (2, 2, 3) */
+	s := rt.NewSphere()
+	b := rt.NewCube()
+	b.SetTransform(rt.Translation(0, 0, 0.5))
+	c := rt.NewCSG(rt.CSGIntersection, s, b)
+	g := rt.NewGroup()
+	g.AddChild(c)
+	g.SetTransform(rt.Scaling(2, 2, 2))
+
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	xs := g.Intersect(r)
+
+	if len(xs) != 2 {
+		t.Fatalf("Error: %v", xs)
+	}
+
+	if math.Abs(xs[0].T-4) > 0.00001 || xs[0].Object != b {
+		t.Errorf("Error: %v", xs[0])
+	}
+
+	if math.Abs(xs[1].T-7) > 0.00001 || xs[1].Object != s {
+		t.Errorf("Error: %v", xs[1])
+	}
+
+	n := s.NormalAt(rt.NewPoint(0, 0, 2))
+	if !n.Equals(rt.NewVector(0, 0, 1)) {
+		t.Errorf("Error: %v", n)
+	}
+
+	box := g.ParentSpaceBounds()
+	if !box.Min.Equals(rt.NewPoint(-2, -2, -2)) || !box.Max.Equals(rt.NewPoint(2, 2, 3)) {
+		t.Errorf("Error: %v", box)
+	}
+}
+
+func TestCSGBoundsFollowOperands(t *testing.T) {
+	/* Scenario: Moving an operand updates the bounds of the CSG */
+	s1 := rt.NewSphere()
+	s2 := rt.NewSphere()
+	c := rt.NewCSG(rt.CSGUnion, s1, s2)
+
+	if box := c.Bounds(); !box.Max.Equals(rt.NewPoint(1, 1, 1)) {
+		t.Errorf("Error: %v", box)
+	}
+
+	s2.SetTransform(rt.Translation(3, 0, 0))
+
+	if box := c.Bounds(); !box.Max.Equals(rt.NewPoint(4, 1, 1)) {
+		t.Errorf("Error: %v", box)
+	}
+}
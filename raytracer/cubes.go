@@ -0,0 +1,66 @@
+package raytracer
+
+import "math"
+
+type Cube struct {
+	shape
+}
+
+func NewCube() *Cube {
+	c := &Cube{}
+	c.shape = newShape(c)
+	return c
+}
+
+func checkAxis(origin, direction, min, max float64) (float64, float64) {
+	if math.Abs(direction) < epsilon {
+		// Parallel to the slab, the ray is either always or never within it.
+		if origin < min || origin > max {
+			return math.Inf(1), math.Inf(-1)
+		}
+		return math.Inf(-1), math.Inf(1)
+	}
+
+	tmin := (min - origin) / direction
+	tmax := (max - origin) / direction
+
+	if tmin > tmax {
+		tmin, tmax = tmax, tmin
+	}
+
+	return tmin, tmax
+}
+
+func (c *Cube) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	xtmin, xtmax := checkAxis(r.Origin.X, r.Direction.X, -1, 1)
+	ytmin, ytmax := checkAxis(r.Origin.Y, r.Direction.Y, -1, 1)
+	ztmin, ztmax := checkAxis(r.Origin.Z, r.Direction.Z, -1, 1)
+
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+
+	if tmin > tmax {
+		return xs
+	}
+
+	return append(xs,
+		NewIntersection(tmin, c),
+		NewIntersection(tmax, c),
+	)
+}
+
+func (c *Cube) LocalNormalAt(p Tuple) Tuple {
+	absX, absY, absZ := math.Abs(p.X), math.Abs(p.Y), math.Abs(p.Z)
+	maxc := math.Max(absX, math.Max(absY, absZ))
+
+	if maxc == absX {
+		return NewVector(p.X, 0, 0)
+	} else if maxc == absY {
+		return NewVector(0, p.Y, 0)
+	}
+	return NewVector(0, 0, p.Z)
+}
+
+func (c *Cube) Bounds() *BoundingBox {
+	return NewBoundingBox(NewPoint(-1, -1, -1), NewPoint(1, 1, 1))
+}
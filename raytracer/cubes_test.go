@@ -0,0 +1,103 @@
+package raytracer_test
+
+import (
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestCubeIntersect(t *testing.T) {
+	/* Scenario Outline: A ray intersects a cube
+	   Given c ← cube()
+	     And r ← ray(<origin>, <direction>)
+	   When xs ← local_intersect(c, r)
+	   Then xs.count = 2
+	     And xs[0].t = <t1>
+	     And xs[1].t = <t2> */
+	examples := []struct {
+		origin, direction rt.Tuple
+		t1, t2            float64
+	}{
+		{rt.NewPoint(5, 0.5, 0), rt.NewVector(-1, 0, 0), 4, 6},
+		{rt.NewPoint(-5, 0.5, 0), rt.NewVector(1, 0, 0), 4, 6},
+		{rt.NewPoint(0.5, 5, 0), rt.NewVector(0, -1, 0), 4, 6},
+		{rt.NewPoint(0.5, -5, 0), rt.NewVector(0, 1, 0), 4, 6},
+		{rt.NewPoint(0.5, 0, 5), rt.NewVector(0, 0, -1), 4, 6},
+		{rt.NewPoint(0.5, 0, -5), rt.NewVector(0, 0, 1), 4, 6},
+		{rt.NewPoint(0, 0.5, 0), rt.NewVector(0, 0, 1), -1, 1},
+	}
+
+	c := rt.NewCube()
+	for _, example := range examples {
+		r := rt.NewRay(example.origin, example.direction)
+
+		xs := c.LocalIntersect(r)
+
+		if len(xs) != 2 {
+			t.Errorf("Error: %v", len(xs))
+			continue
+		}
+
+		if xs[0].T != example.t1 || xs[1].T != example.t2 {
+			t.Errorf("Error: %v %v", xs[0].T, xs[1].T)
+		}
+	}
+}
+
+func TestCubeMiss(t *testing.T) {
+	/* Scenario Outline: A ray misses a cube
+	   Given c ← cube()
+	     And r ← ray(<origin>, <direction>)
+	   When xs ← local_intersect(c, r)
+	   Then xs.count = 0 */
+	examples := []struct {
+		origin, direction rt.Tuple
+	}{
+		{rt.NewPoint(-2, 0, 0), rt.NewVector(0.2673, 0.5345, 0.8018)},
+		{rt.NewPoint(0, -2, 0), rt.NewVector(0.8018, 0.2673, 0.5345)},
+		{rt.NewPoint(0, 0, -2), rt.NewVector(0.5345, 0.8018, 0.2673)},
+		{rt.NewPoint(2, 0, 2), rt.NewVector(0, 0, -1)},
+		{rt.NewPoint(0, 2, 2), rt.NewVector(0, -1, 0)},
+		{rt.NewPoint(2, 2, 0), rt.NewVector(-1, 0, 0)},
+	}
+
+	c := rt.NewCube()
+	for _, example := range examples {
+		r := rt.NewRay(example.origin, example.direction)
+
+		xs := c.LocalIntersect(r)
+
+		if len(xs) != 0 {
+			t.Errorf("Error: %v", len(xs))
+		}
+	}
+}
+
+func TestCubeNormal(t *testing.T) {
+	/* Scenario Outline: The normal on the surface of a cube
+	   Given c ← cube()
+	     And p ← <point>
+	   When normal ← local_normal_at(c, p)
+	   Then normal = <normal> */
+	examples := []struct {
+		point, normal rt.Tuple
+	}{
+		{rt.NewPoint(1, 0.5, -0.8), rt.NewVector(1, 0, 0)},
+		{rt.NewPoint(-1, -0.2, 0.9), rt.NewVector(-1, 0, 0)},
+		{rt.NewPoint(-0.4, 1, -0.1), rt.NewVector(0, 1, 0)},
+		{rt.NewPoint(0.3, -1, -0.7), rt.NewVector(0, -1, 0)},
+		{rt.NewPoint(-0.6, 0.3, 1), rt.NewVector(0, 0, 1)},
+		{rt.NewPoint(0.4, 0.4, -1), rt.NewVector(0, 0, -1)},
+		{rt.NewPoint(1, 1, 1), rt.NewVector(1, 0, 0)},
+		{rt.NewPoint(-1, -1, -1), rt.NewVector(-1, 0, 0)},
+	}
+
+	c := rt.NewCube()
+	for _, example := range examples {
+		normal := c.LocalNormalAt(example.point)
+
+		if !normal.Equals(example.normal) {
+			t.Errorf("Error: %v", normal)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package raytracer
+
+import "math"
+
+type Cylinder struct {
+	shape
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func NewCylinder() *Cylinder {
+	c := &Cylinder{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	c.shape = newShape(c)
+	return c
+}
+
+// checkCap reports whether the point at t along r lies within radius of the
+// y axis, i.e. on the end cap of a cylinder or cone.
+func checkCap(r Ray, t, radius float64) bool {
+	x := r.Origin.X + t*r.Direction.X
+	z := r.Origin.Z + t*r.Direction.Z
+
+	return math.Pow(x, 2)+math.Pow(z, 2) <= math.Pow(radius, 2)+epsilon
+}
+
+func (c *Cylinder) intersectCaps(r Ray, xs Intersections) Intersections {
+	if !c.Closed || math.Abs(r.Direction.Y) < epsilon {
+		return xs
+	}
+
+	t := (c.Minimum - r.Origin.Y) / r.Direction.Y
+	if checkCap(r, t, 1) {
+		xs = append(xs, NewIntersection(t, c))
+	}
+
+	t = (c.Maximum - r.Origin.Y) / r.Direction.Y
+	if checkCap(r, t, 1) {
+		xs = append(xs, NewIntersection(t, c))
+	}
+
+	return xs
+}
+
+func (c *Cylinder) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	a := math.Pow(r.Direction.X, 2) + math.Pow(r.Direction.Z, 2)
+	if math.Abs(a) >= epsilon {
+		b := 2*r.Origin.X*r.Direction.X + 2*r.Origin.Z*r.Direction.Z
+		c2 := math.Pow(r.Origin.X, 2) + math.Pow(r.Origin.Z, 2) - 1
+
+		disc := math.Pow(b, 2) - 4*a*c2
+		if disc < 0 {
+			return xs
+		}
+
+		t0 := (-b - math.Sqrt(disc)) / (2 * a)
+		t1 := (-b + math.Sqrt(disc)) / (2 * a)
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+
+		y0 := r.Origin.Y + t0*r.Direction.Y
+		if c.Minimum < y0 && y0 < c.Maximum {
+			xs = append(xs, NewIntersection(t0, c))
+		}
+
+		y1 := r.Origin.Y + t1*r.Direction.Y
+		if c.Minimum < y1 && y1 < c.Maximum {
+			xs = append(xs, NewIntersection(t1, c))
+		}
+	}
+
+	return c.intersectCaps(r, xs)
+}
+
+func (c *Cylinder) LocalNormalAt(p Tuple) Tuple {
+	dist := math.Pow(p.X, 2) + math.Pow(p.Z, 2)
+
+	if dist < 1 && p.Y >= c.Maximum-epsilon {
+		return NewVector(0, 1, 0)
+	} else if dist < 1 && p.Y <= c.Minimum+epsilon {
+		return NewVector(0, -1, 0)
+	}
+
+	return NewVector(p.X, 0, p.Z)
+}
+
+func (c *Cylinder) Bounds() *BoundingBox {
+	return NewBoundingBox(NewPoint(-1, c.Minimum, -1), NewPoint(1, c.Maximum, 1))
+}
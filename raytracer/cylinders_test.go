@@ -0,0 +1,224 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestCylinderMiss(t *testing.T) {
+	/* Scenario Outline: A ray misses a cylinder
+	   Given cyl ← cylinder()
+	     And direction ← normalize(<direction>)
+	     And r ← ray(<origin>, direction)
+	   When xs ← local_intersect(cyl, r)
+	   Then xs.count = 0 */
+	examples := []struct {
+		origin, direction rt.Tuple
+	}{
+		{rt.NewPoint(1, 0, 0), rt.NewVector(0, 1, 0)},
+		{rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)},
+		{rt.NewPoint(0, 0, -5), rt.NewVector(1, 1, 1)},
+	}
+
+	cyl := rt.NewCylinder()
+	for _, example := range examples {
+		r := rt.NewRay(example.origin, example.direction.Norm())
+
+		xs := cyl.LocalIntersect(r)
+
+		if len(xs) != 0 {
+			t.Errorf("Error: %v", len(xs))
+		}
+	}
+}
+
+func TestCylinderHit(t *testing.T) {
+	/* Scenario Outline: A ray strikes a cylinder
+	   Given cyl ← cylinder()
+	     And direction ← normalize(<direction>)
+	     And r ← ray(<origin>, direction)
+	   When xs ← local_intersect(cyl, r)
+	   Then xs.count = 2
+	     And xs[0].t = <t0>
+	     And xs[1].t = <t1> */
+	examples := []struct {
+		origin, direction rt.Tuple
+		t0, t1            float64
+	}{
+		{rt.NewPoint(1, 0, -5), rt.NewVector(0, 0, 1), 5, 5},
+		{rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1), 4, 6},
+		{rt.NewPoint(0.5, 0, -5), rt.NewVector(0.1, 1, 1), 6.80798, 7.08872},
+	}
+
+	cyl := rt.NewCylinder()
+	for _, example := range examples {
+		r := rt.NewRay(example.origin, example.direction.Norm())
+
+		xs := cyl.LocalIntersect(r)
+
+		if len(xs) != 2 {
+			t.Errorf("Error: %v", len(xs))
+			continue
+		}
+
+		if math.Abs(xs[0].T-example.t0) > 0.00001 || math.Abs(xs[1].T-example.t1) > 0.00001 {
+			t.Errorf("Error: %v %v", xs[0].T, xs[1].T)
+		}
+	}
+}
+
+func TestCylinderNormal(t *testing.T) {
+	/* Scenario Outline: Normal vector on a cylinder
+	   Given cyl ← cylinder()
+	   When n ← local_normal_at(cyl, <point>)
+	   Then n = <normal> */
+	examples := []struct {
+		point, normal rt.Tuple
+	}{
+		{rt.NewPoint(1, 0, 0), rt.NewVector(1, 0, 0)},
+		{rt.NewPoint(0, 5, -1), rt.NewVector(0, 0, -1)},
+		{rt.NewPoint(0, -2, 1), rt.NewVector(0, 0, 1)},
+		{rt.NewPoint(-1, 1, 0), rt.NewVector(-1, 0, 0)},
+	}
+
+	cyl := rt.NewCylinder()
+	for _, example := range examples {
+		n := cyl.LocalNormalAt(example.point)
+
+		if !n.Equals(example.normal) {
+			t.Errorf("Error: %v", n)
+		}
+	}
+}
+
+func TestCylinderDefaultMinMax(t *testing.T) {
+	/* Scenario: The default minimum and maximum for a cylinder
+	   Given cyl ← cylinder()
+	   Then cyl.minimum = -infinity
+	     And cyl.maximum = infinity */
+	cyl := rt.NewCylinder()
+
+	if !math.IsInf(cyl.Minimum, -1) {
+		t.Errorf("Error: %v", cyl.Minimum)
+	}
+
+	if !math.IsInf(cyl.Maximum, 1) {
+		t.Errorf("Error: %v", cyl.Maximum)
+	}
+}
+
+func TestCylinderTruncated(t *testing.T) {
+	/* Scenario Outline: Intersecting a constrained cylinder
+	   Given cyl ← cylinder()
+	     And cyl.minimum ← 1
+	     And cyl.maximum ← 2
+	     And direction ← normalize(<direction>)
+	     And r ← ray(<point>, direction)
+	   When xs ← local_intersect(cyl, r)
+	   Then xs.count = <count> */
+	examples := []struct {
+		point, direction rt.Tuple
+		count            int
+	}{
+		{rt.NewPoint(0, 1.5, 0), rt.NewVector(0.1, 1, 0), 0},
+		{rt.NewPoint(0, 3, -5), rt.NewVector(0, 0, 1), 0},
+		{rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1), 0},
+		{rt.NewPoint(0, 2, -5), rt.NewVector(0, 0, 1), 0},
+		{rt.NewPoint(0, 1, -5), rt.NewVector(0, 0, 1), 0},
+		{rt.NewPoint(0, 1.5, -2), rt.NewVector(0, 0, 1), 2},
+	}
+
+	cyl := rt.NewCylinder()
+	cyl.Minimum = 1
+	cyl.Maximum = 2
+	for _, example := range examples {
+		r := rt.NewRay(example.point, example.direction.Norm())
+
+		xs := cyl.LocalIntersect(r)
+
+		if len(xs) != example.count {
+			t.Errorf("Error: %v", len(xs))
+		}
+	}
+}
+
+func TestCylinderDefaultClosed(t *testing.T) {
+	/* Scenario: The default closed value for a cylinder
+	   Given cyl ← cylinder()
+	   Then cyl.closed = false */
+	cyl := rt.NewCylinder()
+
+	if cyl.Closed {
+		t.Errorf("Error: %v", cyl.Closed)
+	}
+}
+
+func TestCylinderCaps(t *testing.T) {
+	/* Scenario Outline: Intersecting the caps of a closed cylinder
+	   Given cyl ← cylinder()
+	     And cyl.minimum ← 1
+	     And cyl.maximum ← 2
+	     And cyl.closed ← true
+	     And direction ← normalize(<direction>)
+	     And r ← ray(<point>, direction)
+	   When xs ← local_intersect(cyl, r)
+	   Then xs.count = <count> */
+	examples := []struct {
+		point, direction rt.Tuple
+		count            int
+	}{
+		{rt.NewPoint(0, 3, 0), rt.NewVector(0, -1, 0), 2},
+		{rt.NewPoint(0, 3, -2), rt.NewVector(0, -1, 2), 2},
+		{rt.NewPoint(0, 4, -2), rt.NewVector(0, -1, 1), 2},
+		{rt.NewPoint(0, 0, -2), rt.NewVector(0, 1, 2), 2},
+		{rt.NewPoint(0, -1, -2), rt.NewVector(0, 1, 1), 2},
+	}
+
+	cyl := rt.NewCylinder()
+	cyl.Minimum = 1
+	cyl.Maximum = 2
+	cyl.Closed = true
+	for _, example := range examples {
+		r := rt.NewRay(example.point, example.direction.Norm())
+
+		xs := cyl.LocalIntersect(r)
+
+		if len(xs) != example.count {
+			t.Errorf("Error: %v", len(xs))
+		}
+	}
+}
+
+func TestCylinderCapNormal(t *testing.T) {
+	/* Scenario Outline: The normal vector on a cylinder's end caps
+	   Given cyl ← cylinder()
+	     And cyl.minimum ← 1
+	     And cyl.maximum ← 2
+	     And cyl.closed ← true
+	   When n ← local_normal_at(cyl, <point>)
+	   Then n = <normal> */
+	examples := []struct {
+		point, normal rt.Tuple
+	}{
+		{rt.NewPoint(0, 1, 0), rt.NewVector(0, -1, 0)},
+		{rt.NewPoint(0.5, 1, 0), rt.NewVector(0, -1, 0)},
+		{rt.NewPoint(0, 1, 0.5), rt.NewVector(0, -1, 0)},
+		{rt.NewPoint(0, 2, 0), rt.NewVector(0, 1, 0)},
+		{rt.NewPoint(0.5, 2, 0), rt.NewVector(0, 1, 0)},
+		{rt.NewPoint(0, 2, 0.5), rt.NewVector(0, 1, 0)},
+	}
+
+	cyl := rt.NewCylinder()
+	cyl.Minimum = 1
+	cyl.Maximum = 2
+	cyl.Closed = true
+	for _, example := range examples {
+		n := cyl.LocalNormalAt(example.point)
+
+		if !n.Equals(example.normal) {
+			t.Errorf("Error: %v", n)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package raytracer
+
+import (
+	"fmt"
+	"math"
+)
+
+// ToneMapper compresses linear radiance, which can exceed 1, into [0, 1].
+type ToneMapper func(c *Color) *Color
+
+// ClampToneMap cuts off everything above 1.
+func ClampToneMap(c *Color) *Color {
+	return &Color{clamp01(c.R), clamp01(c.G), clamp01(c.B)}
+}
+
+// ReinhardToneMap maps x to x / (1 + x), which compresses highlights
+// smoothly but also darkens the mid tones.
+func ReinhardToneMap(c *Color) *Color {
+	reinhard := func(x float64) float64 {
+		x = math.Max(0, x)
+		return x / (1 + x)
+	}
+
+	return &Color{reinhard(c.R), reinhard(c.G), reinhard(c.B)}
+}
+
+// ACESToneMap is Krzysztof Narkowicz's fit of the ACES filmic curve, with a
+// toe in the shadows and a soft shoulder in the highlights.
+func ACESToneMap(c *Color) *Color {
+	aces := func(x float64) float64 {
+		x = math.Max(0, x)
+		return clamp01((x * (2.51*x + 0.03)) / (x*(2.43*x+0.59) + 0.14))
+	}
+
+	return &Color{aces(c.R), aces(c.G), aces(c.B)}
+}
+
+func ToneMapperByName(name string) (ToneMapper, error) {
+	switch name {
+	case "clamp":
+		return ClampToneMap, nil
+	case "reinhard":
+		return ReinhardToneMap, nil
+	case "aces":
+		return ACESToneMap, nil
+	}
+
+	return nil, fmt.Errorf("unknown tone mapper %q", name)
+}
+
+// Transfer is the curve applied to the tone mapped colors before they are
+// quantized to 8 bits.
+type Transfer int
+
+const (
+	// DefaultTransfer leaves the curve to the encoder: PNG, JPEG and GIF
+	// use sRGB, which is what image viewers expect of them, and the Netpbm
+	// formats write the colors unchanged, like the PPM files in the book.
+	DefaultTransfer Transfer = iota
+	// SRGBTransfer applies the sRGB curve.
+	SRGBTransfer
+	// LinearTransfer writes the tone mapped colors unchanged.
+	LinearTransfer
+)
+
+func TransferByName(name string) (Transfer, error) {
+	switch name {
+	case "default":
+		return DefaultTransfer, nil
+	case "srgb":
+		return SRGBTransfer, nil
+	case "linear":
+		return LinearTransfer, nil
+	}
+
+	return DefaultTransfer, fmt.Errorf("unknown transfer curve %q", name)
+}
+
+// Display describes how the linear colors of a canvas are turned into
+// pixels. The zero value clamps the colors and applies the default transfer
+// curve of the format they are written in.
+type Display struct {
+	// Exposure scales the colors by 2^Exposure before tone mapping.
+	Exposure float64
+	// ToneMapper defaults to ClampToneMap.
+	ToneMapper ToneMapper
+	// Transfer overrides the transfer curve of the encoder.
+	Transfer Transfer
+}
+
+// Apply returns the displayed color, with channels in [0, 1], for c as it
+// is written to PNG, JPEG and GIF images.
+func (d *Display) Apply(c *Color) *Color {
+	return d.apply(c, SRGBTransfer)
+}
+
+// apply is Apply for an encoder whose own transfer curve is fallback.
+func (d *Display) apply(c *Color, fallback Transfer) *Color {
+	if d.Exposure != 0 {
+		c = c.Mul(math.Exp2(d.Exposure))
+	}
+
+	toneMap := d.ToneMapper
+	if toneMap == nil {
+		toneMap = ClampToneMap
+	}
+	c = toneMap(c)
+
+	transfer := d.Transfer
+	if transfer == DefaultTransfer {
+		transfer = fallback
+	}
+	if transfer == SRGBTransfer {
+		c = &Color{SRGBEncode(c.R), SRGBEncode(c.G), SRGBEncode(c.B)}
+	}
+
+	return c
+}
+
+// SRGBEncode applies the sRGB transfer curve to a linear value in [0, 1].
+func SRGBEncode(x float64) float64 {
+	if x <= 0.0031308 {
+		return 12.92 * x
+	}
+
+	return 1.055*math.Pow(x, 1/2.4) - 0.055
+}
+
+// SRGBDecode turns an sRGB encoded value in [0, 1] back to linear.
+func SRGBDecode(x float64) float64 {
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
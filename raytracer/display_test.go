@@ -0,0 +1,145 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestToneMappers(t *testing.T) {
+	examples := []struct {
+		name     string
+		input    float64
+		expected float64
+	}{
+		{"clamp", 0.5, 0.5},
+		{"clamp", 4, 1},
+		{"clamp", -1, 0},
+		{"reinhard", 0, 0},
+		{"reinhard", 1, 0.5},
+		{"reinhard", 3, 0.75},
+		{"aces", 0, 0},
+		{"aces", 0.18, 0.26690},
+		{"aces", 1, 0.80380},
+		{"aces", 100, 1},
+	}
+
+	for _, example := range examples {
+		toneMap, err := rt.ToneMapperByName(example.name)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		c := toneMap(&rt.Color{example.input, example.input, example.input})
+		if math.Abs(c.R-example.expected) > 0.0001 || c.R != c.G || c.G != c.B {
+			t.Errorf("Error: %v %v", example, c)
+		}
+	}
+
+	if _, err := rt.ToneMapperByName("drago"); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestSRGB(t *testing.T) {
+	examples := []struct {
+		linear, encoded float64
+	}{
+		{0, 0},
+		{0.001, 0.01292},
+		{0.18, 0.46135},
+		{0.5, 0.73536},
+		{1, 1},
+	}
+
+	for _, example := range examples {
+		if e := rt.SRGBEncode(example.linear); math.Abs(e-example.encoded) > 0.0001 {
+			t.Errorf("Error: %v %v", example, e)
+		}
+
+		if l := rt.SRGBDecode(example.encoded); math.Abs(l-example.linear) > 0.0001 {
+			t.Errorf("Error: %v %v", example, l)
+		}
+	}
+}
+
+func TestDisplayDefault(t *testing.T) {
+	/* The zero Display clamps the colors and applies the sRGB curve */
+	d := rt.Display{}
+
+	c := d.Apply(&rt.Color{0.25, 1.5, -0.5})
+
+	if !c.Equals(&rt.Color{rt.SRGBEncode(0.25), 1, 0}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestDisplayLinear(t *testing.T) {
+	d := rt.Display{Transfer: rt.LinearTransfer}
+
+	c := d.Apply(&rt.Color{0.25, 1.5, -0.5})
+
+	if !c.Equals(&rt.Color{0.25, 1, 0}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestDisplayTransfer(t *testing.T) {
+	/* PNG uses the sRGB curve and PPM none, unless Transfer picks one for
+	   both */
+	c := rt.NewCanvas(1, 1)
+	c.SetAt(0, 0, &rt.Color{0.5, 0.5, 0.5})
+
+	examples := []struct {
+		name string
+		png  uint8
+		ppm  string
+	}{
+		{"default", 188, "128 128 128"},
+		{"srgb", 188, "188 188 188"},
+		{"linear", 128, "128 128 128"},
+	}
+
+	for _, example := range examples {
+		transfer, err := rt.TransferByName(example.name)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		c.Display.Transfer = transfer
+
+		if g := c.Image().RGBAAt(0, 0).G; g != example.png {
+			t.Errorf("Error: %v %v", example.name, g)
+		}
+		if ppm := c.ToPPM(); ppm != "P3\n1 1\n255\n"+example.ppm+"\n" {
+			t.Errorf("Error: %v %q", example.name, ppm)
+		}
+	}
+
+	if _, err := rt.TransferByName("gamma"); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestDisplayNaN(t *testing.T) {
+	c := rt.NewCanvas(1, 1)
+	c.SetAt(0, 0, &rt.Color{math.NaN(), 1, 0})
+
+	if rgba := c.Image().RGBAAt(0, 0); rgba.R != 0 || rgba.G != 255 {
+		t.Errorf("Error: %v", rgba)
+	}
+	if ppm := c.ToPPM(); ppm != "P3\n1 1\n255\n0 255 0\n" {
+		t.Errorf("Error: %q", ppm)
+	}
+}
+
+func TestDisplayExposure(t *testing.T) {
+	d := rt.Display{Exposure: -1, ToneMapper: rt.ReinhardToneMap}
+
+	c := d.Apply(&rt.Color{2, 0, 6})
+
+	expected := &rt.Color{rt.SRGBEncode(0.5), 0, rt.SRGBEncode(0.75)}
+	if !c.Equals(expected) {
+		t.Errorf("Error: %v", c)
+	}
+}
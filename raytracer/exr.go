@@ -0,0 +1,445 @@
+package raytracer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// EXRCompression is the compression method of the pixel data in an OpenEXR
+// image.
+type EXRCompression byte
+
+const (
+	// EXRNoCompression stores every scanline as it is.
+	EXRNoCompression EXRCompression = 0
+	// EXRZipsCompression deflates every scanline on its own.
+	EXRZipsCompression EXRCompression = 2
+	// EXRZipCompression deflates blocks of 16 scanlines, which compresses
+	// better than EXRZipsCompression.
+	EXRZipCompression EXRCompression = 3
+)
+
+const exrMagic = 20000630
+
+const (
+	exrUint  = 0
+	exrHalf  = 1
+	exrFloat = 2
+)
+
+// linesPerBlock returns how many scanlines are stored together in a chunk.
+func (c EXRCompression) linesPerBlock() int {
+	if c == EXRZipCompression {
+		return 16
+	}
+
+	return 1
+}
+
+type exrAttribute struct {
+	name, kind string
+	value      []byte
+}
+
+// EncodeEXR writes c as a single part scanline OpenEXR image with 32 bit
+// float R, G and B channels, uncompressed or ZIP compressed.
+func EncodeEXR(w io.Writer, c *Canvas, compression EXRCompression) error {
+	if compression != EXRNoCompression && compression != EXRZipsCompression && compression != EXRZipCompression {
+		return fmt.Errorf("exr: unsupported compression %d", compression)
+	}
+
+	var channels bytes.Buffer
+	for _, name := range []string{"B", "G", "R"} {
+		channels.WriteString(name)
+		channels.WriteByte(0)
+		binary.Write(&channels, binary.LittleEndian, []int32{exrFloat, 0, 1, 1})
+	}
+	channels.WriteByte(0)
+
+	box := func() []byte {
+		b := make([]byte, 16)
+		binary.LittleEndian.PutUint32(b[8:], uint32(c.Width-1))
+		binary.LittleEndian.PutUint32(b[12:], uint32(c.Height-1))
+		return b
+	}
+	float := func(f float32) []byte {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, math.Float32bits(f))
+		return b
+	}
+
+	attributes := []exrAttribute{
+		{"channels", "chlist", channels.Bytes()},
+		{"compression", "compression", []byte{byte(compression)}},
+		{"dataWindow", "box2i", box()},
+		{"displayWindow", "box2i", box()},
+		{"lineOrder", "lineOrder", []byte{0}},
+		{"pixelAspectRatio", "float", float(1)},
+		{"screenWindowCenter", "v2f", make([]byte, 8)},
+		{"screenWindowWidth", "float", float(1)},
+	}
+
+	var header bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, []int32{exrMagic, 2})
+	for _, attribute := range attributes {
+		header.WriteString(attribute.name)
+		header.WriteByte(0)
+		header.WriteString(attribute.kind)
+		header.WriteByte(0)
+		binary.Write(&header, binary.LittleEndian, int32(len(attribute.value)))
+		header.Write(attribute.value)
+	}
+	header.WriteByte(0)
+
+	lines := compression.linesPerBlock()
+	blocks := (c.Height + lines - 1) / lines
+
+	chunks := make([][]byte, blocks)
+	for block := range chunks {
+		first := block * lines
+		last := first + lines
+		if last > c.Height {
+			last = c.Height
+		}
+
+		data := make([]byte, 0, (last-first)*c.Width*12)
+		for y := first; y < last; y++ {
+			data = appendEXRScanline(data, c, y)
+		}
+		if compression != EXRNoCompression {
+			data = exrZip(data)
+		}
+
+		chunk := make([]byte, 8, 8+len(data))
+		binary.LittleEndian.PutUint32(chunk, uint32(first))
+		binary.LittleEndian.PutUint32(chunk[4:], uint32(len(data)))
+		chunks[block] = append(chunk, data...)
+	}
+
+	offsets := make([]uint64, blocks)
+	offset := uint64(header.Len() + blocks*8)
+	for block, chunk := range chunks {
+		offsets[block] = offset
+		offset += uint64(len(chunk))
+	}
+	binary.Write(&header, binary.LittleEndian, offsets)
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendEXRScanline appends row y of c to data, one channel after another
+// in alphabetical order.
+func appendEXRScanline(data []byte, c *Canvas, y int) []byte {
+	value := make([]byte, 4)
+	for _, channel := range []func(*Color) float64{
+		func(c *Color) float64 { return c.B },
+		func(c *Color) float64 { return c.G },
+		func(c *Color) float64 { return c.R },
+	} {
+		for x := 0; x < c.Width; x++ {
+			binary.LittleEndian.PutUint32(value, math.Float32bits(float32(channel(c.GetAt(x, y)))))
+			data = append(data, value...)
+		}
+	}
+
+	return data
+}
+
+// exrZip compresses a block the way OpenEXR does: the bytes are split into
+// even and odd halves, delta encoded and then deflated. Blocks that don't get
+// smaller are stored as they are.
+func exrZip(data []byte) []byte {
+	tmp := make([]byte, len(data))
+	half := (len(data) + 1) / 2
+	for i, b := range data {
+		if i%2 == 0 {
+			tmp[i/2] = b
+		} else {
+			tmp[half+i/2] = b
+		}
+	}
+
+	for i := len(tmp) - 1; i > 0; i-- {
+		tmp[i] = tmp[i] - tmp[i-1] + 128
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(tmp)
+	zw.Close()
+
+	if compressed.Len() >= len(data) {
+		return data
+	}
+
+	return compressed.Bytes()
+}
+
+func exrUnzip(data []byte, size int) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	tmp := make([]byte, size)
+	if _, err := io.ReadFull(zr, tmp); err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(tmp); i++ {
+		tmp[i] = tmp[i-1] + tmp[i] - 128
+	}
+
+	out := make([]byte, size)
+	half := (size + 1) / 2
+	for i := range out {
+		if i%2 == 0 {
+			out[i] = tmp[i/2]
+		} else {
+			out[i] = tmp[half+i/2]
+		}
+	}
+
+	return out, nil
+}
+
+type exrChannel struct {
+	name      string
+	pixelType int32
+}
+
+func (ch exrChannel) size() int {
+	if ch.pixelType == exrHalf {
+		return 2
+	}
+
+	return 4
+}
+
+// DecodeEXR reads a single part scanline OpenEXR image that is uncompressed
+// or ZIP compressed, with half, float or uint R, G and B channels, or a
+// single Y channel.
+func DecodeEXR(r io.Reader) (*Canvas, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 8 || binary.LittleEndian.Uint32(data) != exrMagic {
+		return nil, fmt.Errorf("exr: not an OpenEXR file")
+	}
+	if version := binary.LittleEndian.Uint32(data[4:]); version&0xff != 2 || version&0x1e00 != 0 {
+		return nil, fmt.Errorf("exr: only single part scanline images are supported")
+	}
+
+	pos := 8
+	readString := func() (string, error) {
+		end := bytes.IndexByte(data[pos:], 0)
+		if end < 0 {
+			return "", fmt.Errorf("exr: truncated header")
+		}
+		s := string(data[pos : pos+end])
+		pos += end + 1
+		return s, nil
+	}
+
+	var channels []exrChannel
+	compression := EXRCompression(255)
+	var xMin, yMin, xMax, yMax int32
+	lineOrder := byte(0)
+	haveWindow := false
+
+	for {
+		name, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			break
+		}
+		if _, err := readString(); err != nil {
+			return nil, err
+		}
+		if pos+4 > len(data) {
+			return nil, fmt.Errorf("exr: truncated header")
+		}
+		size := int(binary.LittleEndian.Uint32(data[pos:]))
+		pos += 4
+		if size < 0 || pos+size > len(data) {
+			return nil, fmt.Errorf("exr: truncated header")
+		}
+		value := data[pos : pos+size]
+		pos += size
+
+		switch name {
+		case "channels":
+			for len(value) > 1 {
+				end := bytes.IndexByte(value, 0)
+				if end < 0 || len(value) < end+17 {
+					return nil, fmt.Errorf("exr: invalid channel list")
+				}
+				pixelType := int32(binary.LittleEndian.Uint32(value[end+1:]))
+				if xs, ys := binary.LittleEndian.Uint32(value[end+9:]), binary.LittleEndian.Uint32(value[end+13:]); xs != 1 || ys != 1 {
+					return nil, fmt.Errorf("exr: subsampled channels are not supported")
+				}
+				channels = append(channels, exrChannel{string(value[:end]), pixelType})
+				value = value[end+17:]
+			}
+		case "compression":
+			if len(value) != 1 {
+				return nil, fmt.Errorf("exr: invalid compression")
+			}
+			compression = EXRCompression(value[0])
+		case "dataWindow":
+			if len(value) != 16 {
+				return nil, fmt.Errorf("exr: invalid data window")
+			}
+			xMin = int32(binary.LittleEndian.Uint32(value))
+			yMin = int32(binary.LittleEndian.Uint32(value[4:]))
+			xMax = int32(binary.LittleEndian.Uint32(value[8:]))
+			yMax = int32(binary.LittleEndian.Uint32(value[12:]))
+			haveWindow = true
+		case "lineOrder":
+			if len(value) == 1 {
+				lineOrder = value[0]
+			}
+		}
+	}
+
+	if compression != EXRNoCompression && compression != EXRZipsCompression && compression != EXRZipCompression {
+		return nil, fmt.Errorf("exr: unsupported compression %d", compression)
+	}
+	if !haveWindow || xMax < xMin || yMax < yMin {
+		return nil, fmt.Errorf("exr: missing or empty data window")
+	}
+	if lineOrder > 1 {
+		return nil, fmt.Errorf("exr: unsupported line order %d", lineOrder)
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("exr: no channels")
+	}
+	for _, ch := range channels {
+		if ch.pixelType < exrUint || ch.pixelType > exrFloat {
+			return nil, fmt.Errorf("exr: channel %s has unknown pixel type %d", ch.name, ch.pixelType)
+		}
+	}
+
+	// The window may span the whole int32 range, which overflows int32.
+	width64, height64 := int64(xMax)-int64(xMin)+1, int64(yMax)-int64(yMin)+1
+	if !validDecodedSize(width64, height64, len(channels)) {
+		return nil, fmt.Errorf("exr: invalid size %dx%d", width64, height64)
+	}
+	width, height := int(width64), int(height64)
+	c := NewCanvas(width, height)
+
+	lineSize := 0
+	for _, ch := range channels {
+		lineSize += ch.size() * width
+	}
+
+	lines := compression.linesPerBlock()
+	blocks := (height + lines - 1) / lines
+	if pos+blocks*8 > len(data) {
+		return nil, fmt.Errorf("exr: truncated offset table")
+	}
+
+	for block := 0; block < blocks; block++ {
+		offset := int(binary.LittleEndian.Uint64(data[pos+block*8:]))
+		if offset < 0 || offset+8 > len(data) {
+			return nil, fmt.Errorf("exr: chunk %d out of range", block)
+		}
+
+		first := int(int32(binary.LittleEndian.Uint32(data[offset:]))) - int(yMin)
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		if first < 0 || first >= height || size < 0 || offset+8+size > len(data) {
+			return nil, fmt.Errorf("exr: invalid chunk %d", block)
+		}
+		chunk := data[offset+8 : offset+8+size]
+
+		count := lines
+		if first+count > height {
+			count = height - first
+		}
+
+		expected := count * lineSize
+		if size != expected {
+			if compression == EXRNoCompression {
+				return nil, fmt.Errorf("exr: chunk %d has %d bytes, expected %d", block, size, expected)
+			}
+			if chunk, err = exrUnzip(chunk, expected); err != nil {
+				return nil, fmt.Errorf("exr: chunk %d: %v", block, err)
+			}
+		}
+
+		for line := 0; line < count; line++ {
+			decodeEXRScanline(c, first+line, channels, chunk[line*lineSize:(line+1)*lineSize])
+		}
+	}
+
+	return c, nil
+}
+
+func decodeEXRScanline(c *Canvas, y int, channels []exrChannel, line []byte) {
+	pixels := c.pixels[y*c.Width : (y+1)*c.Width]
+
+	pos := 0
+	for _, ch := range channels {
+		for x := range pixels {
+			var v float64
+			switch ch.pixelType {
+			case exrHalf:
+				v = halfToFloat(binary.LittleEndian.Uint16(line[pos:]))
+			case exrFloat:
+				v = float64(math.Float32frombits(binary.LittleEndian.Uint32(line[pos:])))
+			default:
+				v = float64(binary.LittleEndian.Uint32(line[pos:]))
+			}
+			pos += ch.size()
+
+			switch ch.name {
+			case "R":
+				pixels[x].R = v
+			case "G":
+				pixels[x].G = v
+			case "B":
+				pixels[x].B = v
+			case "Y":
+				pixels[x] = Color{v, v, v}
+			}
+		}
+	}
+}
+
+// halfToFloat converts an IEEE 754 half precision float.
+func halfToFloat(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exponent := int(h>>10) & 0x1f
+	mantissa := float64(h & 0x3ff)
+
+	switch exponent {
+	case 0:
+		return sign * math.Ldexp(mantissa, -24)
+	case 0x1f:
+		if mantissa == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+
+	return sign * math.Ldexp(1+mantissa/1024, exponent-15)
+}
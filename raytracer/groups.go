@@ -0,0 +1,153 @@
+package raytracer
+
+import "sort"
+
+type Group struct {
+	shape
+	Children []Shape
+
+	bounds *BoundingBox
+}
+
+func NewGroup() *Group {
+	g := &Group{bounds: NewEmptyBoundingBox()}
+	g.shape = newShape(g)
+	return g
+}
+
+func (g *Group) AddChild(children ...Shape) {
+	for _, child := range children {
+		child.SetParent(g)
+		g.Children = append(g.Children, child)
+	}
+	g.updateBounds()
+}
+
+// updateBounds recomputes the bounds of g and all of its ancestors, which is
+// needed whenever a descendant is added or moved. The bounds are kept up to
+// date eagerly so Bounds never writes, and groups can be shared by the
+// goroutines of Camera.Render.
+func (g *Group) updateBounds() {
+	box := NewEmptyBoundingBox()
+	for _, child := range g.Children {
+		box.AddBox(child.ParentSpaceBounds())
+	}
+	g.bounds = box
+
+	if g.Parent != nil {
+		updateParentBounds(g.Parent)
+	}
+}
+
+func (g *Group) Includes(other Shape) bool {
+	for _, child := range g.Children {
+		if child.Includes(other) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *Group) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	if !g.Bounds().Intersects(r) {
+		return xs
+	}
+
+	start := len(xs)
+	for _, child := range g.Children {
+		xs = child.AppendIntersections(xs, r)
+	}
+	xs[start:].Sort()
+
+	return xs
+}
+
+func (g *Group) LocalNormalAt(p Tuple) Tuple {
+	panic("Group has no normal, normals are computed on its children")
+}
+
+func (g *Group) Bounds() *BoundingBox {
+	return g.bounds
+}
+
+// Partition removes the children of g that have finite bounds and splits them
+// in two halves at the median of their centroids along the widest axis.
+// Children with infinite bounds, like planes, stay in g.
+func (g *Group) Partition() ([]Shape, []Shape) {
+	type bounded struct {
+		shape    Shape
+		centroid Tuple
+	}
+
+	children := []bounded{}
+	unbounded := []Shape{}
+
+	centroidBox := NewEmptyBoundingBox()
+	for _, child := range g.Children {
+		box := child.ParentSpaceBounds()
+		if !box.IsFinite() {
+			unbounded = append(unbounded, child)
+			continue
+		}
+
+		centroid := box.Centroid()
+		centroidBox.AddPoint(centroid)
+		children = append(children, bounded{child, centroid})
+	}
+
+	dx := centroidBox.Max.X - centroidBox.Min.X
+	dy := centroidBox.Max.Y - centroidBox.Min.Y
+	dz := centroidBox.Max.Z - centroidBox.Min.Z
+
+	axis := func(p Tuple) float64 { return p.X }
+	if dy >= dx && dy >= dz {
+		axis = func(p Tuple) float64 { return p.Y }
+	} else if dz >= dx && dz >= dy {
+		axis = func(p Tuple) float64 { return p.Z }
+	}
+
+	sort.Slice(children, func(a, b int) bool {
+		return axis(children[a].centroid) < axis(children[b].centroid)
+	})
+
+	g.Children = unbounded
+	g.updateBounds()
+
+	half := len(children) / 2
+	left := make([]Shape, half)
+	right := make([]Shape, len(children)-half)
+	for idx, child := range children {
+		if idx < half {
+			left[idx] = child.shape
+		} else {
+			right[idx-half] = child.shape
+		}
+	}
+
+	return left, right
+}
+
+func (g *Group) MakeSubgroup(children ...Shape) {
+	subgroup := NewGroup()
+	subgroup.AddChild(children...)
+	g.AddChild(subgroup)
+}
+
+// Divide turns g into a bounding volume hierarchy, recursively splitting
+// groups with at least threshold children into two subgroups.
+func (g *Group) Divide(threshold int) {
+	if threshold <= len(g.Children) && len(g.Children) > 1 {
+		left, right := g.Partition()
+		if len(left) > 0 {
+			g.MakeSubgroup(left...)
+		}
+		if len(right) > 0 {
+			g.MakeSubgroup(right...)
+		}
+	}
+
+	for _, child := range g.Children {
+		child.Divide(threshold)
+	}
+}
@@ -0,0 +1,256 @@
+package raytracer_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestNewGroup(t *testing.T) {
+	/* Scenario: Creating a new group
+	   Given g ← group()
+	   Then g.transform = identity_matrix
+	     And g is empty */
+	g := rt.NewGroup()
+
+	if !g.Transform.Equals(rt.Identity()) {
+		t.Errorf("Error: %v", g.Transform)
+	}
+
+	if len(g.Children) != 0 {
+		t.Errorf("Error: %v", g.Children)
+	}
+}
+
+func TestShapeDefaultParent(t *testing.T) {
+	/* Scenario: A shape has a parent attribute
+	   Given s ← test_shape()
+	   Then s.parent is nothing */
+	s := rt.NewSphere()
+
+	if s.GetParent() != nil {
+		t.Errorf("Error: %v", s.GetParent())
+	}
+}
+
+func TestGroupAddChild(t *testing.T) {
+	/* Scenario: Adding a child to a group
+	   Given g ← group()
+	     And s ← test_shape()
+	   When add_child(g, s)
+	   Then g is not empty
+	     And g includes s
+	     And s.parent = g */
+	g := rt.NewGroup()
+	s := rt.NewSphere()
+
+	g.AddChild(s)
+
+	if len(g.Children) != 1 || g.Children[0] != s {
+		t.Errorf("Error: %v", g.Children)
+	}
+
+	if s.GetParent() != g {
+		t.Errorf("Error: %v", s.GetParent())
+	}
+}
+
+func TestGroupIntersectEmpty(t *testing.T) {
+	/* Scenario: Intersecting a ray with an empty group
+	   Given g ← group()
+	     And r ← ray(point(0, 0, 0), vector(0, 0, 1))
+	   When xs ← local_intersect(g, r)
+	   Then xs is empty */
+	g := rt.NewGroup()
+	r := rt.NewRay(rt.NewPoint(0, 0, 0), rt.NewVector(0, 0, 1))
+
+	xs := g.LocalIntersect(r)
+
+	if len(xs) != 0 {
+		t.Errorf("Error: %v", len(xs))
+	}
+}
+
+func TestGroupIntersectNonEmpty(t *testing.T) {
+	/* Scenario: Intersecting a ray with a nonempty group
+	   Given g ← group()
+	     And s1 ← sphere()
+	     And s2 ← sphere()
+	     And set_transform(s2, translation(0, 0, -3))
+	     And s3 ← sphere()
+	     And set_transform(s3, translation(5, 0, 0))
+	     And add_child(g, s1)
+	     And add_child(g, s2)
+	     And add_child(g, s3)
+	   When r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And xs ← local_intersect(g, r)
+	   Then xs.count = 4
+	     And xs[0].object = s2
+	     And xs[1].object = s2
+	     And xs[2].object = s1
+	     And xs[3].object = s1 */
+	g := rt.NewGroup()
+	s1 := rt.NewSphere()
+	s2 := rt.NewSphere()
+	s2.SetTransform(rt.Translation(0, 0, -3))
+	s3 := rt.NewSphere()
+	s3.SetTransform(rt.Translation(5, 0, 0))
+	g.AddChild(s1, s2, s3)
+
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	xs := g.LocalIntersect(r)
+
+	if len(xs) != 4 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	if xs[0].Object != s2 || xs[1].Object != s2 || xs[2].Object != s1 || xs[3].Object != s1 {
+		t.Errorf("Error: %v", xs)
+	}
+}
+
+func TestGroupIntersectTransformed(t *testing.T) {
+	/* Scenario: Intersecting a transformed group
+	   Given g ← group()
+	     And set_transform(g, scaling(2, 2, 2))
+	     And s ← sphere()
+	     And set_transform(s, translation(5, 0, 0))
+	     And add_child(g, s)
+	   When r ← ray(point(10, 0, -10), vector(0, 0, 1))
+	     And xs ← intersect(g, r)
+	   Then xs.count = 2 */
+	g := rt.NewGroup()
+	g.SetTransform(rt.Scaling(2, 2, 2))
+	s := rt.NewSphere()
+	s.SetTransform(rt.Translation(5, 0, 0))
+	g.AddChild(s)
+
+	r := rt.NewRay(rt.NewPoint(10, 0, -10), rt.NewVector(0, 0, 1))
+	xs := g.Intersect(r)
+
+	if len(xs) != 2 {
+		t.Errorf("Error: %v", len(xs))
+	}
+}
+
+func TestWorldToObject(t *testing.T) {
+	/* Scenario: Converting a point from world to object space
+	   Given g1 ← group()
+	     And set_transform(g1, rotation_y(π/2))
+	     And g2 ← group()
+	     And set_transform(g2, scaling(2, 2, 2))
+	     And add_child(g1, g2)
+	     And s ← sphere()
+	     And set_transform(s, translation(5, 0, 0))
+	     And add_child(g2, s)
+	   When p ← world_to_object(s, point(-2, 0, -10))
+	   Then p = point(0, 0, -1) */
+	g1 := rt.NewGroup()
+	g1.SetTransform(rt.RotationY(math.Pi / 2))
+	g2 := rt.NewGroup()
+	g2.SetTransform(rt.Scaling(2, 2, 2))
+	g1.AddChild(g2)
+	s := rt.NewSphere()
+	s.SetTransform(rt.Translation(5, 0, 0))
+	g2.AddChild(s)
+
+	p := s.WorldToObject(rt.NewPoint(-2, 0, -10))
+
+	if !p.Equals(rt.NewPoint(0, 0, -1)) {
+		t.Errorf("Error: %v", p)
+	}
+}
+
+func TestNormalToWorld(t *testing.T) {
+	/* Scenario: Converting a normal from object to world space
+	   Given g1 ← group()
+	     And set_transform(g1, rotation_y(π/2))
+	     And g2 ← group()
+	     And set_transform(g2, scaling(1, 2, 3))
+	     And add_child(g1, g2)
+	     And s ← sphere()
+	     And set_transform(s, translation(5, 0, 0))
+	     And add_child(g2, s)
+	   When n ← normal_to_world(s, vector(√3/3, √3/3, √3/3))
+	   Then n = vector(0.2857, 0.4286, -0.8571) */
+	g1 := rt.NewGroup()
+	g1.SetTransform(rt.RotationY(math.Pi / 2))
+	g2 := rt.NewGroup()
+	g2.SetTransform(rt.Scaling(1, 2, 3))
+	g1.AddChild(g2)
+	s := rt.NewSphere()
+	s.SetTransform(rt.Translation(5, 0, 0))
+	g2.AddChild(s)
+
+	n := s.NormalToWorld(rt.NewVector(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3))
+
+	if math.Abs(n.X-0.2857) > 0.0001 || math.Abs(n.Y-0.4286) > 0.0001 || math.Abs(n.Z+0.8571) > 0.0001 {
+		t.Errorf("Error: %v", n)
+	}
+}
+
+func TestNormalOnChildObject(t *testing.T) {
+	/* Scenario: Finding the normal on a child object
+	   Given g1 ← group()
+	     And set_transform(g1, rotation_y(π/2))
+	     And g2 ← group()
+	     And set_transform(g2, scaling(1, 2, 3))
+	     And add_child(g1, g2)
+	     And s ← sphere()
+	     And set_transform(s, translation(5, 0, 0))
+	     And add_child(g2, s)
+	   When n ← normal_at(s, point(1.7321, 1.1547, -5.5774))
+	   Then n = vector(0.2857, 0.4286, -0.8571) */
+	g1 := rt.NewGroup()
+	g1.SetTransform(rt.RotationY(math.Pi / 2))
+	g2 := rt.NewGroup()
+	g2.SetTransform(rt.Scaling(1, 2, 3))
+	g1.AddChild(g2)
+	s := rt.NewSphere()
+	s.SetTransform(rt.Translation(5, 0, 0))
+	g2.AddChild(s)
+
+	n := s.NormalAt(rt.NewPoint(1.7321, 1.1547, -5.5774))
+
+	if math.Abs(n.X-0.2857) > 0.0001 || math.Abs(n.Y-0.4286) > 0.0001 || math.Abs(n.Z+0.8571) > 0.0001 {
+		t.Errorf("Error: %v", n)
+	}
+}
+
+func TestObjToGroup(t *testing.T) {
+	/* Scenario: Converting an OBJ file to a group
+	   Given file ← the file "triangles.obj"
+	     And parser ← parse_obj_file(file)
+	   When g ← obj_to_group(parser)
+	   Then g includes "FirstGroup" from parser
+	     And g includes "SecondGroup" from parser */
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	g := parser.ToGroup()
+
+	if len(g.Children) != 2 {
+		t.Fatalf("Error: %v", g.Children)
+	}
+
+	for idx, name := range []string{"FirstGroup", "SecondGroup"} {
+		child := g.Children[idx].(*rt.Group)
+		if child.Children[0] != parser.Groups[name][0] {
+			t.Errorf("Error: %v", child.Children)
+		}
+	}
+}
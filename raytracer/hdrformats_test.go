@@ -0,0 +1,256 @@
+package raytracer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+// hdrCanvas returns a canvas with a gradient, values above 1 and a few
+// repeated colors, so run length encoding has something to do.
+func hdrCanvas(width, height int) *rt.Canvas {
+	c := rt.NewCanvas(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x > width/2 {
+				c.SetAt(x, y, &rt.Color{0.25, 0.5, 1})
+				continue
+			}
+			c.SetAt(x, y, &rt.Color{float64(x) * 1.5, float64(y) / 10, 0.001 * float64(x+y)})
+		}
+	}
+
+	return c
+}
+
+// canvasesMatch compares the colors of a and b relative to the brightest
+// channel of each pixel, as RGBE shares one exponent between all three.
+func canvasesMatch(a, b *rt.Canvas, tolerance float64) bool {
+	if a.Width != b.Width || a.Height != b.Height {
+		return false
+	}
+	for y := 0; y < a.Height; y++ {
+		for x := 0; x < a.Width; x++ {
+			ca, cb := a.GetAt(x, y), b.GetAt(x, y)
+			scale := math.Max(1, math.Max(ca.R, math.Max(ca.G, ca.B)))
+			for _, pair := range [][2]float64{{ca.R, cb.R}, {ca.G, cb.G}, {ca.B, cb.B}} {
+				if math.Abs(pair[0]-pair[1]) > tolerance*scale {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func TestHDRFormatsRoundTrip(t *testing.T) {
+	/* Scenario: High dynamic range formats keep values above 1
+	   Given c ← canvas(20, 5) with a gradient up to 15
+	   When c is encoded and decoded again as pfm, hdr or exr
+	   Then the colors match c */
+	examples := []struct {
+		format    string
+		tolerance float64
+	}{
+		{"pfm", 1e-6},
+		{"hdr", 0.01},
+		{"exr", 1e-6},
+		{"exr-uncompressed", 1e-6},
+	}
+
+	for _, size := range [][2]int{{20, 5}, {5, 3}, {40, 37}} {
+		c := hdrCanvas(size[0], size[1])
+
+		for _, example := range examples {
+			var buf bytes.Buffer
+			if err := c.Encode(&buf, example.format); err != nil {
+				t.Fatalf("Error: %v %v", example.format, err)
+			}
+
+			format := strings.TrimSuffix(example.format, "-uncompressed")
+			decoded, err := rt.DecodeCanvas(&buf, format)
+			if err != nil {
+				t.Fatalf("Error: %v %v", example.format, err)
+			}
+			if !canvasesMatch(c, decoded, example.tolerance) {
+				t.Errorf("Error: %v %v", example.format, size)
+			}
+		}
+	}
+}
+
+func TestEncodeEXRCompression(t *testing.T) {
+	c := rt.NewCanvas(64, 64)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			c.SetAt(x, y, &rt.Color{2, 1, 0.5})
+		}
+	}
+
+	var raw, zipped, zips bytes.Buffer
+	if err := rt.EncodeEXR(&raw, c, rt.EXRNoCompression); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if err := rt.EncodeEXR(&zipped, c, rt.EXRZipCompression); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if err := rt.EncodeEXR(&zips, c, rt.EXRZipsCompression); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if zipped.Len() >= raw.Len()/10 {
+		t.Errorf("Error: %v %v", zipped.Len(), raw.Len())
+	}
+
+	decoded, err := rt.DecodeEXR(&zips)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !canvasesMatch(c, decoded, 0) {
+		t.Errorf("Error: %v", decoded.GetAt(10, 10))
+	}
+
+	if err := rt.EncodeEXR(&raw, c, 4); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestEncodePFMHeader(t *testing.T) {
+	c := rt.NewCanvas(2, 1)
+	c.SetAt(0, 0, &rt.Color{1, 2, 3})
+
+	var buf bytes.Buffer
+	if err := rt.EncodePFM(&buf, c); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	header := "PF\n2 1\n-1.0\n"
+	if !strings.HasPrefix(buf.String(), header) || buf.Len() != len(header)+2*12 {
+		t.Errorf("Error: %q", buf.String())
+	}
+	if v := math.Float32frombits(binary.LittleEndian.Uint32(buf.Bytes()[len(header)+4:])); v != 2 {
+		t.Errorf("Error: %v", v)
+	}
+}
+
+func TestDecodePFMGrayscaleBigEndian(t *testing.T) {
+	/* Scenario: Reading a big endian grayscale float map
+	   Given a Pf file with scale 1.0 and rows stored bottom up
+	   When it is decoded
+	   Then the bottom row comes last in the canvas */
+	var buf bytes.Buffer
+	buf.WriteString("Pf\n1 2\n1.0\n")
+	binary.Write(&buf, binary.BigEndian, []float32{0.5, 4})
+
+	c, err := rt.DecodePFM(&buf)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if top := c.GetAt(0, 0); !top.Equals(&rt.Color{4, 4, 4}) {
+		t.Errorf("Error: %v", top)
+	}
+	if bottom := c.GetAt(0, 1); !bottom.Equals(&rt.Color{0.5, 0.5, 0.5}) {
+		t.Errorf("Error: %v", bottom)
+	}
+}
+
+func TestDecodeHDRFlatScanlines(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("#?RGBE\nGAMMA=1.0\n\n-Y 1 +X 2\n")
+	buf.Write([]byte{128, 64, 0, 129, 0, 0, 0, 0})
+
+	c, err := rt.DecodeHDR(&buf)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if color := c.GetAt(0, 0); !color.Equals(&rt.Color{1.00390625, 0.50390625, 0.00390625}) {
+		t.Errorf("Error: %v", color)
+	}
+	if color := c.GetAt(1, 0); !color.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+// exrHeader returns the header of an uncompressed scanline OpenEXR image
+// with the given data window and float channels, without any pixels.
+func exrHeader(xMin, yMin, xMax, yMax int32, channels ...string) string {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, []uint32{20000630, 2})
+	attribute := func(name, kind string, value []byte) {
+		buf.WriteString(name + "\x00" + kind + "\x00")
+		binary.Write(&buf, binary.LittleEndian, uint32(len(value)))
+		buf.Write(value)
+	}
+
+	var list bytes.Buffer
+	for _, name := range channels {
+		list.WriteString(name + "\x00")
+		binary.Write(&list, binary.LittleEndian, []int32{2, 0, 1, 1})
+	}
+	list.WriteByte(0)
+	attribute("channels", "chlist", list.Bytes())
+
+	var window bytes.Buffer
+	binary.Write(&window, binary.LittleEndian, []int32{xMin, yMin, xMax, yMax})
+	attribute("dataWindow", "box2i", window.Bytes())
+	attribute("compression", "compression", []byte{0})
+	buf.WriteByte(0)
+
+	return buf.String()
+}
+
+func TestDecodeHDRFormatsInvalid(t *testing.T) {
+	examples := []struct {
+		format string
+		data   string
+	}{
+		{"pfm", "P6\n1 1\n255\n"},
+		{"pfm", "PF\n0 1\n-1.0\n"},
+		{"pfm", "PF\n1 1\n-1.0\n\x00\x00"},
+		{"hdr", "P6\n"},
+		{"hdr", "#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n\x00\x00\x00\x00"},
+		{"hdr", "#?RADIANCE\n\n+X 1 -Y 1\n\x00\x00\x00\x00"},
+		{"hdr", "#?RADIANCE\n\n-Y 2 +X 1\n\x00\x00\x00\x00"},
+		{"exr", "not an exr file"},
+		{"exr", "\x76\x2f\x31\x01\x02\x00\x00\x00\x00"},
+		{"pfm", "PF\n100000 100000\n-1.0\n"},
+		{"hdr", "#?RADIANCE\n\n-Y 100000 +X 100000\n"},
+		{"exr", exrHeader(-1<<31, 0, 1<<31-2, 0, "R")},
+		{"exr", exrHeader(0, 0, 1<<20, 1<<20, "R", "G", "B")},
+		{"exr", exrHeader(0, 0, 0, 0)},
+	}
+
+	for _, example := range examples {
+		if _, err := rt.DecodeCanvas(strings.NewReader(example.data), example.format); err == nil {
+			t.Errorf("Error: %q", example.data)
+		}
+	}
+}
+
+func TestSaveAndLoadHDRFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	c := hdrCanvas(9, 4)
+	for _, name := range []string{"out.pfm", "out.hdr", "out.exr"} {
+		filename := filepath.Join(dir, name)
+		if err := c.Save(filename); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		loaded, err := rt.LoadCanvas(filename)
+		if err != nil {
+			t.Fatalf("Error: %v %v", name, err)
+		}
+		if !canvasesMatch(c, loaded, 0.01) {
+			t.Errorf("Error: %v", name)
+		}
+	}
+}
@@ -1,42 +1,177 @@
 package raytracer
 
-type Intersected interface {
-	NormalAt(p *Tuple) *Tuple
-	GetMaterial() *Material
-}
+import (
+	"math"
+	"sort"
+)
 
 type Intersection struct {
 	T      float64
-	Object Intersected
+	Object Shape
+	U, V   float64
 }
 
-type Intersections []*Intersection
+// Intersections holds intersections by value, so collecting them only
+// allocates when the slice has to grow.
+type Intersections []Intersection
 
-func NewIntersections(is ...*Intersection) Intersections {
+func NewIntersections(is ...Intersection) Intersections {
 	intersections := make(Intersections, len(is))
+	copy(intersections, is)
 
-	for idx, intersection := range is {
-		intersections[idx] = intersection
-	}
 	return intersections
 }
 
-func NewIntersection(t float64, object Intersected) *Intersection {
-	return &Intersection{t, object}
+func NewIntersection(t float64, object Shape) Intersection {
+	return Intersection{T: t, Object: object}
+}
+
+func NewIntersectionWithUV(t float64, object Shape, u, v float64) Intersection {
+	return Intersection{t, object, u, v}
 }
 
+// Hit returns the intersection with the lowest non-negative t, pointing into
+// i, or nil when there is none.
 func (i Intersections) Hit() *Intersection {
 	var hit *Intersection
 
-	for _, intersection := range i {
-		if intersection.T < 0 {
+	for idx := range i {
+		if i[idx].T < 0 {
 			continue
 		}
-		if hit == nil || intersection.T < hit.T {
-			hit = intersection
-			continue
+		if hit == nil || i[idx].T < hit.T {
+			hit = &i[idx]
 		}
 	}
 
 	return hit
 }
+
+func (i Intersections) Len() int           { return len(i) }
+func (i Intersections) Less(a, b int) bool { return i[a].T < i[b].T }
+func (i Intersections) Swap(a, b int)      { i[a], i[b] = i[b], i[a] }
+
+// Sort orders the intersections by t. The short lists of a single ray are
+// insertion sorted, which unlike sort.Sort doesn't allocate.
+func (i Intersections) Sort() {
+	if len(i) > 16 {
+		sort.Stable(i)
+		return
+	}
+
+	for a := 1; a < len(i); a++ {
+		for b := a; b > 0 && i[b].T < i[b-1].T; b-- {
+			i[b], i[b-1] = i[b-1], i[b]
+		}
+	}
+}
+
+type Computations struct {
+	T          float64
+	Object     Shape
+	Point      Tuple
+	OverPoint  Tuple
+	UnderPoint Tuple
+	EyeV       Tuple
+	NormalV    Tuple
+	ReflectV   Tuple
+	Inside     bool
+	N1, N2     float64
+}
+
+func (i *Intersection) PrepareComputations(r Ray, xs Intersections) *Computations {
+	comps := &Computations{
+		T:      i.T,
+		Object: i.Object,
+	}
+
+	comps.Point = r.Pos(comps.T)
+	comps.EyeV = r.Direction.Neg()
+	comps.NormalV = comps.Object.NormalAtHit(comps.Point, i)
+
+	if comps.NormalV.Dot(comps.EyeV) < 0 {
+		comps.Inside = true
+		comps.NormalV = comps.NormalV.Neg()
+	}
+
+	comps.ReflectV = r.Direction.Reflect(comps.NormalV)
+	comps.OverPoint = comps.Point.Add(comps.NormalV.Mul(epsilon))
+	comps.UnderPoint = comps.Point.Sub(comps.NormalV.Mul(epsilon))
+
+	comps.N1, comps.N2 = xs.refractiveIndices(xs.index(i))
+
+	return comps
+}
+
+// index returns the position of i in xs, or -1. The intersections returned
+// by Hit point into the list, which tells apart intersections with the same
+// t and object, like the two of a ray grazing a sphere. Copies are found by
+// value.
+func (xs Intersections) index(i *Intersection) int {
+	for idx := range xs {
+		if &xs[idx] == i {
+			return idx
+		}
+	}
+	for idx := range xs {
+		if xs[idx] == *i {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// refractiveIndices walks xs up to the hit at index hit, tracking which
+// objects the ray is inside of, to find the refractive indices on both
+// sides of the hit.
+func (xs Intersections) refractiveIndices(hit int) (float64, float64) {
+	n1, n2 := 1.0, 1.0
+	containers := []Shape{}
+
+	for idx, x := range xs {
+		if idx == hit && len(containers) > 0 {
+			n1 = containers[len(containers)-1].GetMaterial().RefractiveIndex
+		}
+
+		found := false
+		for idx, container := range containers {
+			if container == x.Object {
+				containers = append(containers[:idx], containers[idx+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			containers = append(containers, x.Object)
+		}
+
+		if idx == hit {
+			if len(containers) > 0 {
+				n2 = containers[len(containers)-1].GetMaterial().RefractiveIndex
+			}
+			break
+		}
+	}
+
+	return n1, n2
+}
+
+// Schlick approximates the Fresnel reflectance at the hit.
+func (comps *Computations) Schlick() float64 {
+	cos := comps.EyeV.Dot(comps.NormalV)
+
+	if comps.N1 > comps.N2 {
+		n := comps.N1 / comps.N2
+		sin2t := math.Pow(n, 2) * (1 - math.Pow(cos, 2))
+		if sin2t > 1 {
+			return 1
+		}
+
+		cos = math.Sqrt(1 - sin2t)
+	}
+
+	r0 := math.Pow((comps.N1-comps.N2)/(comps.N1+comps.N2), 2)
+
+	return r0 + (1-r0)*math.Pow(1-cos, 5)
+}
@@ -1,6 +1,7 @@
 package raytracer_test
 
 import (
+	"math"
 	"testing"
 
 	rt "github.com/gumuz/go-raytracer/raytracer"
@@ -67,7 +68,7 @@ func TestIntersectionsAllPositive(t *testing.T) {
 
 	i := xs.Hit()
 
-	if i != i1 {
+	if i == nil || *i != i1 {
 		t.Errorf("Error: %v", i)
 	}
 }
@@ -86,7 +87,7 @@ func TestIntersectionsSomePositive(t *testing.T) {
 
 	i := xs.Hit()
 
-	if i != i2 {
+	if i == nil || *i != i2 {
 		t.Errorf("Error: %v", i)
 	}
 }
@@ -129,7 +130,406 @@ func TestIntersectionsLowestPositive(t *testing.T) {
 
 	i := xs.Hit()
 
-	if i != i4 {
+	if i == nil || *i != i4 {
 		t.Errorf("Error: %v", i)
 	}
 }
+
+func TestPrepareComputations(t *testing.T) {
+	/* Scenario: Precomputing the state of an intersection
+	   Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And shape ← sphere()
+	     And i ← intersection(4, shape)
+	   When comps ← prepare_computations(i, r)
+	   Then comps.t = i.t
+	     And comps.object = i.object
+	     And comps.point = point(0, 0, -1)
+	     And comps.eyev = vector(0, 0, -1)
+	     And comps.normalv = vector(0, 0, -1) */
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	shape := rt.NewSphere()
+	i := rt.NewIntersection(4, shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+
+	if comps.T != i.T {
+		t.Errorf("Error: %v", comps.T)
+	}
+
+	if comps.Object != i.Object {
+		t.Errorf("Error: %v", comps.Object)
+	}
+
+	if !comps.Point.Equals(rt.NewPoint(0, 0, -1)) {
+		t.Errorf("Error: %v", comps.Point)
+	}
+
+	if !comps.EyeV.Equals(rt.NewVector(0, 0, -1)) {
+		t.Errorf("Error: %v", comps.EyeV)
+	}
+
+	if !comps.NormalV.Equals(rt.NewVector(0, 0, -1)) {
+		t.Errorf("Error: %v", comps.NormalV)
+	}
+}
+
+func TestPrepareComputationsOutside(t *testing.T) {
+	/* Scenario: The hit, when an intersection occurs on the outside
+	   Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And shape ← sphere()
+	     And i ← intersection(4, shape)
+	   When comps ← prepare_computations(i, r)
+	   Then comps.inside = false */
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	shape := rt.NewSphere()
+	i := rt.NewIntersection(4, shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+
+	if comps.Inside {
+		t.Errorf("Error: %v", comps.Inside)
+	}
+}
+
+func TestPrepareComputationsInside(t *testing.T) {
+	/* Scenario: The hit, when an intersection occurs on the inside
+	   Given r ← ray(point(0, 0, 0), vector(0, 0, 1))
+	     And shape ← sphere()
+	     And i ← intersection(1, shape)
+	   When comps ← prepare_computations(i, r)
+	   Then comps.point = point(0, 0, 1)
+	     And comps.eyev = vector(0, 0, -1)
+	     And comps.inside = true
+	     And comps.normalv = vector(0, 0, -1) */
+	r := rt.NewRay(rt.NewPoint(0, 0, 0), rt.NewVector(0, 0, 1))
+	shape := rt.NewSphere()
+	i := rt.NewIntersection(1, shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+
+	if !comps.Point.Equals(rt.NewPoint(0, 0, 1)) {
+		t.Errorf("Error: %v", comps.Point)
+	}
+
+	if !comps.EyeV.Equals(rt.NewVector(0, 0, -1)) {
+		t.Errorf("Error: %v", comps.EyeV)
+	}
+
+	if !comps.Inside {
+		t.Errorf("Error: %v", comps.Inside)
+	}
+
+	if !comps.NormalV.Equals(rt.NewVector(0, 0, -1)) {
+		t.Errorf("Error: %v", comps.NormalV)
+	}
+}
+
+func TestPrepareComputationsOverPoint(t *testing.T) {
+	/* Scenario: The hit should offset the point
+	   Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And shape ← sphere() with:
+	       | transform | translation(0, 0, 1) |
+	     And i ← intersection(5, shape)
+	   When comps ← prepare_computations(i, r)
+	   Then comps.over_point.z < -EPSILON/2
+	     And comps.point.z > comps.over_point.z */
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	shape := rt.NewSphere()
+	shape.SetTransform(rt.Translation(0, 0, 1))
+	i := rt.NewIntersection(5, shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+
+	if comps.OverPoint.Z >= -0.00001/2 {
+		t.Errorf("Error: %v", comps.OverPoint)
+	}
+
+	if comps.Point.Z <= comps.OverPoint.Z {
+		t.Errorf("Error: %v", comps.Point)
+	}
+}
+
+func TestPrepareComputationsReflectV(t *testing.T) {
+	/* Scenario: Precomputing the reflection vector
+	   Given shape ← plane()
+	     And r ← ray(point(0, 1, -1), vector(0, -√2/2, √2/2))
+	     And i ← intersection(√2, shape)
+	   When comps ← prepare_computations(i, r)
+	   Then comps.reflectv = vector(0, √2/2, √2/2) */
+	shape := rt.NewPlane()
+	r := rt.NewRay(rt.NewPoint(0, 1, -1), rt.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := rt.NewIntersection(math.Sqrt(2), shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+
+	if !comps.ReflectV.Equals(rt.NewVector(0, math.Sqrt(2)/2, math.Sqrt(2)/2)) {
+		t.Errorf("Error: %v", comps.ReflectV)
+	}
+}
+
+func TestPrepareComputationsN1N2(t *testing.T) {
+	/* Scenario Outline: Finding n1 and n2 at various intersections
+	   Given A ← glass_sphere() with:
+	       | transform                 | scaling(2, 2, 2) |
+	       | material.refractive_index | 1.5              |
+	     And B ← glass_sphere() with:
+	       | transform                 | translation(0, 0, -0.25) |
+	       | material.refractive_index | 2.0                      |
+	     And C ← glass_sphere() with:
+	       | transform                 | translation(0, 0, 0.25) |
+	       | material.refractive_index | 2.5                     |
+	     And r ← ray(point(0, 0, -4), vector(0, 0, 1))
+	     And xs ← intersections(2:A, 2.75:B, 3.25:C, 4.75:B, 5.25:C, 6:A)
+	   When comps ← prepare_computations(xs[<index>], r, xs)
+	   Then comps.n1 = <n1>
+	     And comps.n2 = <n2> */
+	a := newGlassSphere()
+	a.SetTransform(rt.Scaling(2, 2, 2))
+	a.Material.RefractiveIndex = 1.5
+	b := newGlassSphere()
+	b.SetTransform(rt.Translation(0, 0, -0.25))
+	b.Material.RefractiveIndex = 2.0
+	c := newGlassSphere()
+	c.SetTransform(rt.Translation(0, 0, 0.25))
+	c.Material.RefractiveIndex = 2.5
+	r := rt.NewRay(rt.NewPoint(0, 0, -4), rt.NewVector(0, 0, 1))
+	xs := rt.NewIntersections(
+		rt.NewIntersection(2, a),
+		rt.NewIntersection(2.75, b),
+		rt.NewIntersection(3.25, c),
+		rt.NewIntersection(4.75, b),
+		rt.NewIntersection(5.25, c),
+		rt.NewIntersection(6, a),
+	)
+
+	examples := []struct {
+		n1, n2 float64
+	}{
+		{1.0, 1.5},
+		{1.5, 2.0},
+		{2.0, 2.5},
+		{2.5, 2.5},
+		{2.5, 1.5},
+		{1.5, 1.0},
+	}
+
+	for idx, example := range examples {
+		comps := xs[idx].PrepareComputations(r, xs)
+
+		if comps.N1 != example.n1 || comps.N2 != example.n2 {
+			t.Errorf("Error: %v %v %v", idx, comps.N1, comps.N2)
+		}
+	}
+}
+
+func TestPrepareComputationsN1N2Tangent(t *testing.T) {
+	/* Scenario: Finding n1 and n2 where a ray grazes a sphere
+	   Given s ← glass_sphere()
+	     And r ← ray(point(0, 1, -5), vector(0, 0, 1))
+	     And xs ← intersect(s, r)
+	   When comps ← prepare_computations(xs[1], r, xs)
+	   Then xs[0] = xs[1]
+	     And comps.n1 = 1.5
+	     And comps.n2 = 1.0 */
+	s := newGlassSphere()
+	r := rt.NewRay(rt.NewPoint(0, 1, -5), rt.NewVector(0, 0, 1))
+	xs := s.Intersect(r)
+
+	if len(xs) != 2 || xs[0] != xs[1] {
+		t.Fatalf("Error: %v", xs)
+	}
+
+	comps := xs[1].PrepareComputations(r, xs)
+
+	if comps.N1 != 1.5 || comps.N2 != 1.0 {
+		t.Errorf("Error: %v %v", comps.N1, comps.N2)
+	}
+}
+
+func TestPrepareComputationsUnderPoint(t *testing.T) {
+	/* Scenario: The under point is offset below the surface
+	   Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And shape ← glass_sphere() with:
+	       | transform | translation(0, 0, 1) |
+	     And i ← intersection(5, shape)
+	     And xs ← intersections(i)
+	   When comps ← prepare_computations(i, r, xs)
+	   Then comps.under_point.z > EPSILON/2
+	     And comps.point.z < comps.under_point.z */
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	shape := newGlassSphere()
+	shape.SetTransform(rt.Translation(0, 0, 1))
+	i := rt.NewIntersection(5, shape)
+	xs := rt.NewIntersections(i)
+
+	comps := i.PrepareComputations(r, xs)
+
+	if comps.UnderPoint.Z <= 0.00001/2 {
+		t.Errorf("Error: %v", comps.UnderPoint)
+	}
+
+	if comps.Point.Z >= comps.UnderPoint.Z {
+		t.Errorf("Error: %v", comps.Point)
+	}
+}
+
+func TestSchlickTotalInternalReflection(t *testing.T) {
+	/* Scenario: The Schlick approximation under total internal reflection
+	   Given shape ← glass_sphere()
+	     And r ← ray(point(0, 0, √2/2), vector(0, 1, 0))
+	     And xs ← intersections(-√2/2:shape, √2/2:shape)
+	   When comps ← prepare_computations(xs[1], r, xs)
+	     And reflectance ← schlick(comps)
+	   Then reflectance = 1.0 */
+	shape := newGlassSphere()
+	r := rt.NewRay(rt.NewPoint(0, 0, math.Sqrt(2)/2), rt.NewVector(0, 1, 0))
+	xs := rt.NewIntersections(
+		rt.NewIntersection(-math.Sqrt(2)/2, shape),
+		rt.NewIntersection(math.Sqrt(2)/2, shape),
+	)
+
+	comps := xs[1].PrepareComputations(r, xs)
+	reflectance := comps.Schlick()
+
+	if reflectance != 1.0 {
+		t.Errorf("Error: %v", reflectance)
+	}
+}
+
+func TestSchlickPerpendicular(t *testing.T) {
+	/* Scenario: The Schlick approximation with a perpendicular viewing angle
+	   Given shape ← glass_sphere()
+	     And r ← ray(point(0, 0, 0), vector(0, 1, 0))
+	     And xs ← intersections(-1:shape, 1:shape)
+	   When comps ← prepare_computations(xs[1], r, xs)
+	     And reflectance ← schlick(comps)
+	   Then reflectance = 0.04 */
+	shape := newGlassSphere()
+	r := rt.NewRay(rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0))
+	xs := rt.NewIntersections(
+		rt.NewIntersection(-1, shape),
+		rt.NewIntersection(1, shape),
+	)
+
+	comps := xs[1].PrepareComputations(r, xs)
+	reflectance := comps.Schlick()
+
+	if math.Abs(reflectance-0.04) > 0.00001 {
+		t.Errorf("Error: %v", reflectance)
+	}
+}
+
+func TestSchlickSmallAngle(t *testing.T) {
+	/* Scenario: The Schlick approximation with small angle and n2 > n1
+	   Given shape ← glass_sphere()
+	     And r ← ray(point(0, 0.99, -2), vector(0, 0, 1))
+	     And xs ← intersections(1.8589:shape)
+	   When comps ← prepare_computations(xs[0], r, xs)
+	     And reflectance ← schlick(comps)
+	   Then reflectance = 0.48873 */
+	shape := newGlassSphere()
+	r := rt.NewRay(rt.NewPoint(0, 0.99, -2), rt.NewVector(0, 0, 1))
+	xs := rt.NewIntersections(rt.NewIntersection(1.8589, shape))
+
+	comps := xs[0].PrepareComputations(r, xs)
+	reflectance := comps.Schlick()
+
+	if math.Abs(reflectance-0.48873) > 0.00001 {
+		t.Errorf("Error: %v", reflectance)
+	}
+}
+
+// intersectGroup returns a group with one of every kind of shape lined up
+// along the z axis, all hit by intersectRay.
+func intersectGroup() *rt.Group {
+	sphere := rt.NewSphere()
+	sphere.SetTransform(rt.Translation(0, 0, 2))
+
+	cylinder := rt.NewCylinder()
+	cylinder.Minimum, cylinder.Maximum, cylinder.Closed = -1, 1, true
+	cylinder.SetTransform(rt.Translation(0, 0, 5).Mul(rt.RotationX(math.Pi / 2)))
+
+	cone := rt.NewCone()
+	cone.Minimum, cone.Maximum, cone.Closed = -1, 0, true
+	cone.SetTransform(rt.Translation(0, 0, 8).Mul(rt.RotationX(math.Pi / 2)))
+
+	hole := rt.NewSphere()
+	hole.SetTransform(rt.Scaling(0.5, 0.5, 0.5))
+	csg := rt.NewCSG(rt.CSGDifference, rt.NewCube(), hole)
+	csg.SetTransform(rt.Translation(0, 0, 11))
+
+	triangle := rt.NewTriangle(rt.NewPoint(0, 1, 14), rt.NewPoint(-1, -1, 14), rt.NewPoint(1, -1, 14))
+	smooth := rt.NewSmoothTriangle(
+		rt.NewPoint(0, 1, 15), rt.NewPoint(-1, -1, 15), rt.NewPoint(1, -1, 15),
+		rt.NewVector(0, 0, -1), rt.NewVector(0, 0, -1), rt.NewVector(0, 0, -1),
+	)
+
+	plane := rt.NewPlane()
+	plane.SetTransform(rt.Translation(0, 0, 20).Mul(rt.RotationX(math.Pi / 2)))
+
+	sub := rt.NewGroup()
+	sub.AddChild(triangle, smooth)
+
+	g := rt.NewGroup()
+	g.AddChild(sphere, cylinder, cone, csg, sub, plane)
+	g.Bounds()
+
+	return g
+}
+
+var intersectRay = rt.NewRay(rt.NewPoint(0.1, 0.1, -5), rt.NewVector(0, 0, 1))
+
+func TestAppendIntersections(t *testing.T) {
+	g := intersectGroup()
+
+	xs := g.Intersect(intersectRay)
+	if len(xs) != 13 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	// Appending keeps what is already in the list and sorts only the new
+	// intersections.
+	existing := rt.NewIntersections(rt.NewIntersection(100, g))
+	appended := g.AppendIntersections(existing, intersectRay)
+	if len(appended) != len(xs)+1 || appended[0].T != 100 {
+		t.Fatalf("Error: %v", appended)
+	}
+
+	for idx := range xs {
+		if appended[idx+1] != xs[idx] {
+			t.Errorf("Error: %v %v", appended[idx+1], xs[idx])
+		}
+	}
+}
+
+func TestAppendIntersectionsDoesNotAllocate(t *testing.T) {
+	g := intersectGroup()
+	xs := g.Intersect(intersectRay)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		xs = g.AppendIntersections(xs[:0], intersectRay)
+		xs.Hit()
+	})
+
+	if allocs != 0 {
+		t.Errorf("Error: %v", allocs)
+	}
+}
+
+func BenchmarkGroupIntersect(b *testing.B) {
+	g := intersectGroup()
+
+	for i := 0; i < b.N; i++ {
+		g.Intersect(intersectRay)
+	}
+}
+
+func BenchmarkGroupAppendIntersections(b *testing.B) {
+	g := intersectGroup()
+	xs := rt.NewIntersections()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		xs = g.AppendIntersections(xs[:0], intersectRay)
+	}
+}
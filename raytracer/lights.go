@@ -1,10 +1,10 @@
 package raytracer
 
 type PointLight struct {
-	Position  *Tuple
+	Position  Tuple
 	Intensity *Color
 }
 
-func NewPointLight(position *Tuple, intensity *Color) *PointLight {
+func NewPointLight(position Tuple, intensity *Color) *PointLight {
 	return &PointLight{position, intensity}
 }
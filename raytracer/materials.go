@@ -3,27 +3,39 @@ package raytracer
 import "math"
 
 type Material struct {
-	Color     *Color
-	Ambient   float64
-	Diffuse   float64
-	Specular  float64
-	Shininess float64
+	Color           *Color
+	Pattern         Pattern
+	Ambient         float64
+	Diffuse         float64
+	Specular        float64
+	Shininess       float64
+	Reflective      float64
+	Transparency    float64
+	RefractiveIndex float64
 }
 
 func NewMaterial() *Material {
-	return &Material{&Color{1, 1, 1}, 0.1, 0.9, 0.9, 200.0}
+	return &Material{&Color{1, 1, 1}, nil, 0.1, 0.9, 0.9, 200.0, 0.0, 0.0, 1.0}
 }
 
 func (m *Material) Equals(b *Material) bool {
-	return m.Color.Equals(b.Color) && m.Ambient == b.Ambient && m.Diffuse == b.Diffuse && m.Specular == b.Specular && m.Shininess == b.Shininess
+	return m.Color.Equals(b.Color) && m.Pattern == b.Pattern && m.Ambient == b.Ambient && m.Diffuse == b.Diffuse && m.Specular == b.Specular && m.Shininess == b.Shininess && m.Reflective == b.Reflective && m.Transparency == b.Transparency && m.RefractiveIndex == b.RefractiveIndex
 }
 
-func (m *Material) Lighting(l *PointLight, p *Tuple, eyev *Tuple, normalv *Tuple) *Color {
-	effectiveColor := m.Color.Prod(l.Intensity)
+func (m *Material) Lighting(object Shape, l *PointLight, p Tuple, eyev Tuple, normalv Tuple, inShadow bool) *Color {
+	color := m.Color
+	if m.Pattern != nil {
+		color = m.Pattern.PatternAtShape(object, p)
+	}
+
+	effectiveColor := color.Prod(l.Intensity)
 
 	lightv := l.Position.Sub(p).Norm()
 
 	ambient := effectiveColor.Mul(m.Ambient)
+	if inShadow {
+		return ambient
+	}
 
 	lightDotNormal := lightv.Dot(normalv)
 	diffuse := &Color{0, 0, 0}
@@ -38,6 +38,33 @@ func TestDefaultMaterial(t *testing.T) {
 	}
 }
 
+func TestDefaultMaterialReflective(t *testing.T) {
+	/* Scenario: Reflectivity for the default material
+	   Given m ← material()
+	   Then m.reflective = 0.0 */
+	m := rt.NewMaterial()
+
+	if m.Reflective != 0.0 {
+		t.Errorf("Error: %v", m.Reflective)
+	}
+}
+
+func TestDefaultMaterialTransparency(t *testing.T) {
+	/* Scenario: Transparency and Refractive Index for the default material
+	   Given m ← material()
+	   Then m.transparency = 0.0
+	     And m.refractive_index = 1.0 */
+	m := rt.NewMaterial()
+
+	if m.Transparency != 0.0 {
+		t.Errorf("Error: %v", m.Transparency)
+	}
+
+	if m.RefractiveIndex != 1.0 {
+		t.Errorf("Error: %v", m.RefractiveIndex)
+	}
+}
+
 func TestLightingEyeBetweenLightAndSurface(t *testing.T) {
 	/* Scenario: Lighting with the eye between the light and the surface
 	   Given eyev ← vector(0, 0, -1)
@@ -52,7 +79,7 @@ func TestLightingEyeBetweenLightAndSurface(t *testing.T) {
 	normalv := rt.NewVector(0, 0, -1)
 	light := rt.NewPointLight(rt.NewPoint(0, 0, -10), &rt.Color{1, 1, 1})
 
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(rt.NewSphere(), light, position, eyev, normalv, false)
 	if !result.Equals(&rt.Color{1.9, 1.9, 1.9}) {
 		t.Errorf("Error: %v", result)
 	}
@@ -71,7 +98,7 @@ func TestLightingEyeBetweenLightAndSurfaceEyeOffset45(t *testing.T) {
 	normalv := rt.NewVector(0, 0, -1)
 	light := rt.NewPointLight(rt.NewPoint(0, 0, -10), &rt.Color{1, 1, 1})
 
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(rt.NewSphere(), light, position, eyev, normalv, false)
 	if !result.Equals(&rt.Color{1.0, 1.0, 1.0}) {
 		t.Errorf("Error: %v", result)
 	}
@@ -90,7 +117,7 @@ func TestLightingEyeOppositeSurfaceLightOffset45(t *testing.T) {
 	normalv := rt.NewVector(0, 0, -1)
 	light := rt.NewPointLight(rt.NewPoint(0, 10, -10), &rt.Color{1, 1, 1})
 
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(rt.NewSphere(), light, position, eyev, normalv, false)
 	if !result.Equals(&rt.Color{0.7364, 0.7364, 0.7364}) {
 		t.Errorf("Error: %v", result)
 	}
@@ -109,7 +136,7 @@ func TestLightingEyeInPathOfReflectionVector(t *testing.T) {
 	normalv := rt.NewVector(0, 0, -1)
 	light := rt.NewPointLight(rt.NewPoint(0, 10, -10), &rt.Color{1, 1, 1})
 
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(rt.NewSphere(), light, position, eyev, normalv, false)
 	if !result.Equals(&rt.Color{1.6364, 1.6364, 1.6364}) {
 		t.Errorf("Error: %v", result)
 	}
@@ -128,8 +155,65 @@ func TestLightingLightBehindSurface(t *testing.T) {
 	normalv := rt.NewVector(0, 0, -1)
 	light := rt.NewPointLight(rt.NewPoint(0, 0, 10), &rt.Color{1, 1, 1})
 
-	result := m.Lighting(light, position, eyev, normalv)
+	result := m.Lighting(rt.NewSphere(), light, position, eyev, normalv, false)
 	if !result.Equals(&rt.Color{0.1, 0.1, 0.1}) {
 		t.Errorf("Error: %v", result)
 	}
 }
+
+func TestLightingSurfaceInShadow(t *testing.T) {
+	/* Scenario: Lighting with the surface in shadow
+	   Given eyev ← vector(0, 0, -1)
+	     And normalv ← vector(0, 0, -1)
+	     And light ← point_light(point(0, 0, -10), color(1, 1, 1))
+	     And in_shadow ← true
+	   When result ← lighting(m, light, position, eyev, normalv, in_shadow)
+	   Then result = color(0.1, 0.1, 0.1) */
+	m := rt.NewMaterial()
+	position := rt.NewPoint(0, 0, 0)
+
+	eyev := rt.NewVector(0, 0, -1)
+	normalv := rt.NewVector(0, 0, -1)
+	light := rt.NewPointLight(rt.NewPoint(0, 0, -10), &rt.Color{1, 1, 1})
+	inShadow := true
+
+	result := m.Lighting(rt.NewSphere(), light, position, eyev, normalv, inShadow)
+	if !result.Equals(&rt.Color{0.1, 0.1, 0.1}) {
+		t.Errorf("Error: %v", result)
+	}
+}
+
+func TestLightingWithPattern(t *testing.T) {
+	/* Scenario: Lighting with a pattern applied
+	   Given m.pattern ← stripe_pattern(color(1, 1, 1), color(0, 0, 0))
+	     And m.ambient ← 1
+	     And m.diffuse ← 0
+	     And m.specular ← 0
+	     And eyev ← vector(0, 0, -1)
+	     And normalv ← vector(0, 0, -1)
+	     And light ← point_light(point(0, 0, -10), color(1, 1, 1))
+	   When c1 ← lighting(m, light, point(0.9, 0, 0), eyev, normalv, false)
+	     And c2 ← lighting(m, light, point(1.1, 0, 0), eyev, normalv, false)
+	   Then c1 = color(1, 1, 1)
+	     And c2 = color(0, 0, 0) */
+	m := rt.NewMaterial()
+	m.Pattern = rt.NewStripePattern(rt.NewSolidPattern(&rt.Color{1, 1, 1}), rt.NewSolidPattern(&rt.Color{0, 0, 0}))
+	m.Ambient = 1
+	m.Diffuse = 0
+	m.Specular = 0
+	eyev := rt.NewVector(0, 0, -1)
+	normalv := rt.NewVector(0, 0, -1)
+	light := rt.NewPointLight(rt.NewPoint(0, 0, -10), &rt.Color{1, 1, 1})
+	object := rt.NewSphere()
+
+	c1 := m.Lighting(object, light, rt.NewPoint(0.9, 0, 0), eyev, normalv, false)
+	c2 := m.Lighting(object, light, rt.NewPoint(1.1, 0, 0), eyev, normalv, false)
+
+	if !c1.Equals(&rt.Color{1, 1, 1}) {
+		t.Errorf("Error: %v", c1)
+	}
+
+	if !c2.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", c2)
+	}
+}
@@ -2,50 +2,54 @@ package raytracer
 
 import "math"
 
-type Matrix [][]float64
+// Matrix is a 4x4 matrix of transformations. It is a plain array, so
+// matrices are copied rather than shared and never allocated on the heap.
+type Matrix [4][4]float64
 
-func Identity() Matrix {
-	return Matrix4(
-		1, 0, 0, 0,
-		0, 1, 0, 0,
-		0, 0, 1, 0,
-		0, 0, 0, 1,
-	)
-}
+// Matrix3x3 and Matrix2x2 only exist to compute determinants by cofactor
+// expansion.
+type Matrix3x3 [3][3]float64
+type Matrix2x2 [2][2]float64
 
-func matrix(size int, values ...float64) Matrix {
-	matrix := make(Matrix, size)
-	for idx, _ := range matrix {
-		matrix[idx] = make([]float64, size)
+func Identity() Matrix {
+	return Matrix{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
 	}
+}
 
+func Matrix2(values ...float64) Matrix2x2 {
+	var m Matrix2x2
 	for idx, value := range values {
-		matrix[idx/size][idx%size] = value
+		m[idx/2][idx%2] = value
 	}
 
-	return matrix
+	return m
 }
 
-func Matrix2(values ...float64) Matrix {
-	return matrix(2, values...)
-}
+func Matrix3(values ...float64) Matrix3x3 {
+	var m Matrix3x3
+	for idx, value := range values {
+		m[idx/3][idx%3] = value
+	}
 
-func Matrix3(values ...float64) Matrix {
-	return matrix(3, values...)
+	return m
 }
 
 func Matrix4(values ...float64) Matrix {
-	return matrix(4, values...)
+	var m Matrix
+	for idx, value := range values {
+		m[idx/4][idx%4] = value
+	}
+
+	return m
 }
 
 func (m Matrix) Equals(b Matrix) bool {
-	length := len(m)
-	if length != len(b) {
-		return false
-	}
-
-	for row := 0; row < length; row++ {
-		for col := 0; col < length; col++ {
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
 			if math.Abs(m[row][col]-b[row][col]) > epsilon {
 				return false
 			}
@@ -55,39 +59,31 @@ func (m Matrix) Equals(b Matrix) bool {
 }
 
 func (m Matrix) Mul(b Matrix) Matrix {
-	length := len(m)
-	matrix := matrix(length)
+	var matrix Matrix
 
-	for row := 0; row < length; row++ {
-		for col := 0; col < length; col++ {
-			for i := 0; i < length; i++ {
-				matrix[row][col] += m[row][i] * b[i][col]
-			}
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
+			matrix[row][col] = m[row][0]*b[0][col] + m[row][1]*b[1][col] + m[row][2]*b[2][col] + m[row][3]*b[3][col]
 		}
 	}
 
 	return matrix
 }
 
-func (m Matrix) MulT(t *Tuple) *Tuple {
-	in := [4]float64{t.X, t.Y, t.Z, t.W}
-	out := [4]float64{}
-
-	for y := 0; y < 4; y++ {
-		for x := 0; x < 4; x++ {
-			out[y] += m[y][x] * in[x]
-		}
+func (m Matrix) MulT(t Tuple) Tuple {
+	return Tuple{
+		m[0][0]*t.X + m[0][1]*t.Y + m[0][2]*t.Z + m[0][3]*t.W,
+		m[1][0]*t.X + m[1][1]*t.Y + m[1][2]*t.Z + m[1][3]*t.W,
+		m[2][0]*t.X + m[2][1]*t.Y + m[2][2]*t.Z + m[2][3]*t.W,
+		m[3][0]*t.X + m[3][1]*t.Y + m[3][2]*t.Z + m[3][3]*t.W,
 	}
-
-	return &Tuple{out[0], out[1], out[2], out[3]}
 }
 
 func (m Matrix) Trans() Matrix {
-	length := len(m)
-	matrix := matrix(length)
+	var matrix Matrix
 
-	for row := 0; row < length; row++ {
-		for col := 0; col < length; col++ {
+	for row := 0; row < 4; row++ {
+		for col := 0; col < 4; col++ {
 			matrix[col][row] = m[row][col]
 		}
 	}
@@ -96,62 +92,148 @@ func (m Matrix) Trans() Matrix {
 }
 
 func (m Matrix) Det() float64 {
-	length := len(m)
 	det := 0.0
-
-	if length == 2 {
-		det = (m[0][0] * m[1][1]) - (m[0][1] * m[1][0])
-	} else {
-		for col := 0; col < length; col++ {
-			det += m[0][col] * m.Cofact(0, col)
-		}
+	for col := 0; col < 4; col++ {
+		det += m[0][col] * m.Cofact(0, col)
 	}
 
 	return det
 }
 
-func (m Matrix) SubMatrix(row, col int) Matrix {
-	values := []float64{}
+func (m Matrix) SubMatrix(row, col int) Matrix3x3 {
+	var matrix Matrix3x3
 
-	for rowIdx, cols := range m {
-		for colIdx, value := range cols {
-			if rowIdx == row || colIdx == col {
+	for r, subRow := 0, 0; r < 4; r++ {
+		if r == row {
+			continue
+		}
+		for c, subCol := 0, 0; c < 4; c++ {
+			if c == col {
 				continue
 			}
-			values = append(values, value)
+			matrix[subRow][subCol] = m[r][c]
+			subCol++
 		}
+		subRow++
 	}
 
-	return matrix(len(m)-1, values...)
+	return matrix
 }
 
 func (m Matrix) Minor(row, col int) float64 {
-	matrix := m.SubMatrix(row, col)
-
-	return matrix.Det()
+	return m.SubMatrix(row, col).Det()
 }
 
 func (m Matrix) Cofact(row, col int) float64 {
-	if (row+col)%2 == 0 {
-		return m.Minor(row, col)
-	} else {
-		return -m.Minor(row, col)
-	}
+	return cofactorSign(row, col) * m.Minor(row, col)
 }
 
+// Inv returns the inverse of m, found by Gauss-Jordan elimination with
+// partial pivoting. It panics when m is not invertible.
 func (m Matrix) Inv() Matrix {
-	matrix := matrix(len(m))
+	a, inverse := m, Identity()
+
+	for col := 0; col < 4; col++ {
+		pivot := col
+		for row := col + 1; row < 4; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if a[pivot][col] == 0 {
+			panic("Matrix is not invertible")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		inverse[col], inverse[pivot] = inverse[pivot], inverse[col]
+
+		scale := 1 / a[col][col]
+		for c := 0; c < 4; c++ {
+			a[col][c] *= scale
+			inverse[col][c] *= scale
+		}
 
-	det := m.Det()
-	if det == 0 {
-		panic("Matrix is not invertible")
+		for row := 0; row < 4; row++ {
+			if row == col || a[row][col] == 0 {
+				continue
+			}
+			factor := a[row][col]
+			for c := 0; c < 4; c++ {
+				a[row][c] -= factor * a[col][c]
+				inverse[row][c] -= factor * inverse[col][c]
+			}
+		}
 	}
 
-	for row := 0; row < 4; row++ {
-		for col := 0; col < 4; col++ {
-			matrix[col][row] = m.Cofact(row, col) / det
+	return inverse
+}
+
+func (m Matrix3x3) Equals(b Matrix3x3) bool {
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			if math.Abs(m[row][col]-b[row][col]) > epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (m Matrix3x3) Det() float64 {
+	det := 0.0
+	for col := 0; col < 3; col++ {
+		det += m[0][col] * m.Cofact(0, col)
+	}
+
+	return det
+}
+
+func (m Matrix3x3) SubMatrix(row, col int) Matrix2x2 {
+	var matrix Matrix2x2
+
+	for r, subRow := 0, 0; r < 3; r++ {
+		if r == row {
+			continue
+		}
+		for c, subCol := 0, 0; c < 3; c++ {
+			if c == col {
+				continue
+			}
+			matrix[subRow][subCol] = m[r][c]
+			subCol++
 		}
+		subRow++
 	}
 
 	return matrix
 }
+
+func (m Matrix3x3) Minor(row, col int) float64 {
+	return m.SubMatrix(row, col).Det()
+}
+
+func (m Matrix3x3) Cofact(row, col int) float64 {
+	return cofactorSign(row, col) * m.Minor(row, col)
+}
+
+func (m Matrix2x2) Equals(b Matrix2x2) bool {
+	for row := 0; row < 2; row++ {
+		for col := 0; col < 2; col++ {
+			if math.Abs(m[row][col]-b[row][col]) > epsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (m Matrix2x2) Det() float64 {
+	return m[0][0]*m[1][1] - m[0][1]*m[1][0]
+}
+
+func cofactorSign(row, col int) float64 {
+	if (row+col)%2 == 0 {
+		return 1
+	}
+
+	return -1
+}
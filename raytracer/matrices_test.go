@@ -193,13 +193,13 @@ func TestMatrix4MulTuple(t *testing.T) {
 		0, 0, 0, 1,
 	)
 
-	b := &rt.Tuple{
+	b := rt.Tuple{
 		1, 2, 3, 1,
 	}
 
 	result := A.MulT(b)
 
-	expected := &rt.Tuple{
+	expected := rt.Tuple{
 		18, 24, 33, 1,
 	}
 	if !result.Equals(expected) {
@@ -231,7 +231,7 @@ func TestMatrix4MulIdentityTuple(t *testing.T) {
 	/* Scenario: Multiplying the identity matrix by a tuple
 	   Given a ← tuple(1, 2, 3, 4)
 	   Then identity_matrix * a = a */
-	a := &rt.Tuple{1, 2, 3, 4}
+	a := rt.Tuple{1, 2, 3, 4}
 
 	if !rt.Identity().MulT(a).Equals(a) {
 		t.Errorf("Error: %v", rt.Identity().MulT(a))
@@ -697,3 +697,29 @@ func TestMatrix4MulInverse(t *testing.T) {
 		t.Errorf("Error: %v", result)
 	}
 }
+
+func BenchmarkMatrixInverse(b *testing.B) {
+	A := rt.Translation(1, -2, 3).Mul(rt.RotationY(0.5)).Mul(rt.Scaling(2, 3, 4))
+
+	for i := 0; i < b.N; i++ {
+		A.Inv()
+	}
+}
+
+func BenchmarkMatrixMul(b *testing.B) {
+	A := rt.Translation(1, -2, 3).Mul(rt.RotationY(0.5))
+	B := rt.Scaling(2, 3, 4)
+
+	for i := 0; i < b.N; i++ {
+		A.Mul(B)
+	}
+}
+
+func BenchmarkMatrixMulTuple(b *testing.B) {
+	A := rt.Translation(1, -2, 3).Mul(rt.RotationY(0.5))
+	p := rt.NewPoint(1, 2, 3)
+
+	for i := 0; i < b.N; i++ {
+		A.MulT(p)
+	}
+}
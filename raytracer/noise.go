@@ -0,0 +1,82 @@
+package raytracer
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Perlin generates 3D gradient noise following Ken Perlin's improved noise.
+// The permutation table is shuffled from seed, so the same seed always yields
+// the same noise.
+type Perlin struct {
+	perm [512]int
+}
+
+func NewPerlin(seed int64) *Perlin {
+	n := &Perlin{}
+
+	for idx, value := range rand.New(rand.NewSource(seed)).Perm(256) {
+		n.perm[idx] = value
+		n.perm[idx+256] = value
+	}
+
+	return n
+}
+
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+func grad(hash int, x, y, z float64) float64 {
+	h := hash & 15
+
+	u := y
+	if h < 8 {
+		u = x
+	}
+
+	v := z
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+
+	return u + v
+}
+
+// Noise returns the noise value at the given point, roughly within [-1, 1].
+func (n *Perlin) Noise(x, y, z float64) float64 {
+	fx, fy, fz := math.Floor(x), math.Floor(y), math.Floor(z)
+	X, Y, Z := int(fx)&255, int(fy)&255, int(fz)&255
+	x, y, z = x-fx, y-fy, z-fz
+
+	u, v, w := fade(x), fade(y), fade(z)
+
+	p := n.perm
+	A := p[X] + Y
+	AA := p[A] + Z
+	AB := p[A+1] + Z
+	B := p[X+1] + Y
+	BA := p[B] + Z
+	BB := p[B+1] + Z
+
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad(p[AA], x, y, z), grad(p[BA], x-1, y, z)),
+			lerp(u, grad(p[AB], x, y-1, z), grad(p[BB], x-1, y-1, z))),
+		lerp(v,
+			lerp(u, grad(p[AA+1], x, y, z-1), grad(p[BA+1], x-1, y, z-1)),
+			lerp(u, grad(p[AB+1], x, y-1, z-1), grad(p[BB+1], x-1, y-1, z-1))))
+}
@@ -0,0 +1,52 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestPerlinNoiseAtLatticePoints(t *testing.T) {
+	/* Scenario: Gradient noise is zero on integer lattice points */
+	noise := rt.NewPerlin(1)
+
+	for _, p := range [][3]float64{{0, 0, 0}, {1, 2, 3}, {-4, 7, -1}} {
+		if n := noise.Noise(p[0], p[1], p[2]); n != 0 {
+			t.Errorf("Error: %v %v", p, n)
+		}
+	}
+}
+
+func TestPerlinNoiseRange(t *testing.T) {
+	/* Scenario: Noise stays within [-1, 1] and is not constant */
+	noise := rt.NewPerlin(1)
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for x := -5.0; x < 5; x += 0.37 {
+		for y := -5.0; y < 5; y += 0.41 {
+			n := noise.Noise(x, y, 0.5)
+			min = math.Min(min, n)
+			max = math.Max(max, n)
+		}
+	}
+
+	if min < -1 || max > 1 || max-min < 0.5 {
+		t.Errorf("Error: %v %v", min, max)
+	}
+}
+
+func TestPerlinNoiseSeed(t *testing.T) {
+	/* Scenario: Noise is deterministic for a seed and varies between seeds */
+	a := rt.NewPerlin(1)
+	b := rt.NewPerlin(1)
+	c := rt.NewPerlin(2)
+
+	if a.Noise(0.3, 1.7, 2.2) != b.Noise(0.3, 1.7, 2.2) {
+		t.Errorf("Error: %v", b.Noise(0.3, 1.7, 2.2))
+	}
+
+	if a.Noise(0.3, 1.7, 2.2) == c.Noise(0.3, 1.7, 2.2) {
+		t.Errorf("Error: %v", c.Noise(0.3, 1.7, 2.2))
+	}
+}
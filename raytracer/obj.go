@@ -0,0 +1,179 @@
+package raytracer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type ObjFile struct {
+	Vertices     []Tuple
+	Normals      []Tuple
+	Ignored      int
+	DefaultGroup []Shape
+	Groups       map[string][]Shape
+	GroupNames   []string
+}
+
+func ParseObjFile(r io.Reader) (*ObjFile, error) {
+	obj := &ObjFile{Groups: map[string][]Shape{}}
+	current := ""
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var err error
+		switch fields[0] {
+		case "v":
+			var v Tuple
+			if v, err = parseObjTuple(fields[1:]); err == nil {
+				obj.Vertices = append(obj.Vertices, NewPoint(v.X, v.Y, v.Z))
+			}
+		case "vn":
+			var n Tuple
+			if n, err = parseObjTuple(fields[1:]); err == nil {
+				obj.Normals = append(obj.Normals, NewVector(n.X, n.Y, n.Z))
+			}
+		case "f":
+			var triangles []Shape
+			if triangles, err = obj.parseFace(fields[1:]); err == nil {
+				if current == "" {
+					obj.DefaultGroup = append(obj.DefaultGroup, triangles...)
+				} else {
+					obj.Groups[current] = append(obj.Groups[current], triangles...)
+				}
+			}
+		case "g":
+			if len(fields) < 2 {
+				err = fmt.Errorf("missing group name")
+				break
+			}
+			current = fields[1]
+			if _, ok := obj.Groups[current]; !ok {
+				obj.Groups[current] = []Shape{}
+				obj.GroupNames = append(obj.GroupNames, current)
+			}
+		default:
+			obj.Ignored++
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("obj line %d: %v", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func parseObjTuple(fields []string) (Tuple, error) {
+	if len(fields) < 3 {
+		return Tuple{}, fmt.Errorf("expected 3 coordinates, got %d", len(fields))
+	}
+
+	values := [3]float64{}
+	for idx := range values {
+		value, err := strconv.ParseFloat(fields[idx], 64)
+		if err != nil {
+			return Tuple{}, err
+		}
+		values[idx] = value
+	}
+
+	return Tuple{values[0], values[1], values[2], 0}, nil
+}
+
+// Vertex returns the vertex at the given 1-based index, as used by faces.
+func (o *ObjFile) Vertex(idx int) Tuple {
+	return o.Vertices[idx-1]
+}
+
+// Normal returns the vertex normal at the given 1-based index.
+func (o *ObjFile) Normal(idx int) Tuple {
+	return o.Normals[idx-1]
+}
+
+func (o *ObjFile) parseFace(fields []string) ([]Shape, error) {
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("face needs at least 3 vertices, got %d", len(fields))
+	}
+
+	vertices := make([]Tuple, len(fields))
+	normals := make([]Tuple, len(fields))
+	hasNormal := make([]bool, len(fields))
+	for idx, field := range fields {
+		refs := strings.Split(field, "/")
+
+		vertex, err := o.lookup(refs[0], len(o.Vertices))
+		if err != nil {
+			return nil, err
+		}
+		vertices[idx] = o.Vertex(vertex)
+
+		if len(refs) == 3 && refs[2] != "" {
+			normal, err := o.lookup(refs[2], len(o.Normals))
+			if err != nil {
+				return nil, err
+			}
+			normals[idx] = o.Normal(normal)
+			hasNormal[idx] = true
+		}
+	}
+
+	triangles := []Shape{}
+	for idx := 1; idx < len(vertices)-1; idx++ {
+		if hasNormal[0] && hasNormal[idx] && hasNormal[idx+1] {
+			triangles = append(triangles, NewSmoothTriangle(
+				vertices[0], vertices[idx], vertices[idx+1],
+				normals[0], normals[idx], normals[idx+1],
+			))
+		} else {
+			triangles = append(triangles, NewTriangle(vertices[0], vertices[idx], vertices[idx+1]))
+		}
+	}
+
+	return triangles, nil
+}
+
+func (o *ObjFile) lookup(ref string, count int) (int, error) {
+	idx, err := strconv.Atoi(ref)
+	if err != nil {
+		return 0, err
+	}
+
+	if idx < 0 {
+		idx = count + idx + 1
+	}
+
+	if idx < 1 || idx > count {
+		return 0, fmt.Errorf("index %s out of range", ref)
+	}
+
+	return idx, nil
+}
+
+// ToGroup converts the parsed file into a single group, with each named group
+// becoming a child group of its own.
+func (o *ObjFile) ToGroup() *Group {
+	g := NewGroup()
+	g.AddChild(o.DefaultGroup...)
+
+	for _, name := range o.GroupNames {
+		child := NewGroup()
+		child.AddChild(o.Groups[name]...)
+		g.AddChild(child)
+	}
+
+	return g
+}
@@ -0,0 +1,329 @@
+package raytracer_test
+
+import (
+	"strings"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestObjIgnoresUnrecognizedLines(t *testing.T) {
+	/* Scenario: Ignoring unrecognized lines
+	   Given gibberish ← a file containing:
+	     """
+	     There was a young lady named Bright
+	     who traveled much faster than light.
+	     She set out one day
+	     in a relative way,
+	     and came back the previous night.
+	     """
+	   When parser ← parse_obj_file(gibberish)
+	   Then parser should have ignored 5 lines */
+	gibberish := `There was a young lady named Bright
+who traveled much faster than light.
+She set out one day
+in a relative way,
+and came back the previous night.`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(gibberish))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if parser.Ignored != 5 {
+		t.Errorf("Error: %v", parser.Ignored)
+	}
+}
+
+func TestObjVertexRecords(t *testing.T) {
+	/* Scenario: Vertex records
+	   Given file ← a file containing:
+	     """
+	     v -1 1 0
+	     v -1.0000 0.5000 0.0000
+	     v 1 0 0
+	     v 1 1 0
+	     """
+	   When parser ← parse_obj_file(file)
+	   Then parser.vertices[1] = point(-1, 1, 0)
+	     And parser.vertices[2] = point(-1, 0.5, 0)
+	     And parser.vertices[3] = point(1, 0, 0)
+	     And parser.vertices[4] = point(1, 1, 0) */
+	file := `v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	expected := []rt.Tuple{
+		rt.NewPoint(-1, 1, 0),
+		rt.NewPoint(-1, 0.5, 0),
+		rt.NewPoint(1, 0, 0),
+		rt.NewPoint(1, 1, 0),
+	}
+	for idx, vertex := range expected {
+		if !parser.Vertex(idx + 1).Equals(vertex) {
+			t.Errorf("Error: %v", parser.Vertex(idx+1))
+		}
+	}
+}
+
+func TestObjTriangleFaces(t *testing.T) {
+	/* Scenario: Parsing triangle faces
+	   Given file ← a file containing:
+	     """
+	     v -1 1 0
+	     v -1 0 0
+	     v 1 0 0
+	     v 1 1 0
+
+	     f 1 2 3
+	     f 1 3 4
+	     """
+	   When parser ← parse_obj_file(file)
+	     And g ← parser.default_group
+	     And t1 ← first child of g
+	     And t2 ← second child of g
+	   Then t1.p1 = parser.vertices[1]
+	     And t1.p2 = parser.vertices[2]
+	     And t1.p3 = parser.vertices[3]
+	     And t2.p1 = parser.vertices[1]
+	     And t2.p2 = parser.vertices[3]
+	     And t2.p3 = parser.vertices[4] */
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 3
+f 1 3 4`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	g := parser.DefaultGroup
+	if len(g) != 2 {
+		t.Fatalf("Error: %v", len(g))
+	}
+
+	t1 := g[0].(*rt.Triangle)
+	t2 := g[1].(*rt.Triangle)
+
+	if t1.P1 != parser.Vertex(1) || t1.P2 != parser.Vertex(2) || t1.P3 != parser.Vertex(3) {
+		t.Errorf("Error: %v", t1)
+	}
+
+	if t2.P1 != parser.Vertex(1) || t2.P2 != parser.Vertex(3) || t2.P3 != parser.Vertex(4) {
+		t.Errorf("Error: %v", t2)
+	}
+}
+
+func TestObjPolygonFaces(t *testing.T) {
+	/* Scenario: Triangulating polygons
+	   Given file ← a file containing:
+	     """
+	     v -1 1 0
+	     v -1 0 0
+	     v 1 0 0
+	     v 1 1 0
+	     v 0 2 0
+
+	     f 1 2 3 4 5
+	     """
+	   When parser ← parse_obj_file(file)
+	     And g ← parser.default_group
+	     And t1 ← first child of g
+	     And t2 ← second child of g
+	     And t3 ← third child of g
+	   Then t1.p1 = parser.vertices[1]
+	     And t1.p2 = parser.vertices[2]
+	     And t1.p3 = parser.vertices[3]
+	     And t2.p1 = parser.vertices[1]
+	     And t2.p2 = parser.vertices[3]
+	     And t2.p3 = parser.vertices[4]
+	     And t3.p1 = parser.vertices[1]
+	     And t3.p2 = parser.vertices[4]
+	     And t3.p3 = parser.vertices[5] */
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	g := parser.DefaultGroup
+	if len(g) != 3 {
+		t.Fatalf("Error: %v", len(g))
+	}
+
+	for idx, child := range g {
+		tri := child.(*rt.Triangle)
+		if tri.P1 != parser.Vertex(1) || tri.P2 != parser.Vertex(idx+2) || tri.P3 != parser.Vertex(idx+3) {
+			t.Errorf("Error: %v", tri)
+		}
+	}
+}
+
+func TestObjNamedGroups(t *testing.T) {
+	/* Scenario: Triangles in groups
+	   Given file ← the file "triangles.obj"
+	   When parser ← parse_obj_file(file)
+	     And g1 ← "FirstGroup" from parser
+	     And g2 ← "SecondGroup" from parser
+	     And t1 ← first child of g1
+	     And t2 ← first child of g2
+	   Then t1.p1 = parser.vertices[1]
+	     And t1.p2 = parser.vertices[2]
+	     And t1.p3 = parser.vertices[3]
+	     And t2.p1 = parser.vertices[1]
+	     And t2.p2 = parser.vertices[3]
+	     And t2.p3 = parser.vertices[4] */
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	g1 := parser.Groups["FirstGroup"]
+	g2 := parser.Groups["SecondGroup"]
+	if len(g1) != 1 || len(g2) != 1 {
+		t.Fatalf("Error: %v", parser.Groups)
+	}
+
+	t1 := g1[0].(*rt.Triangle)
+	t2 := g2[0].(*rt.Triangle)
+
+	if t1.P1 != parser.Vertex(1) || t1.P2 != parser.Vertex(2) || t1.P3 != parser.Vertex(3) {
+		t.Errorf("Error: %v", t1)
+	}
+
+	if t2.P1 != parser.Vertex(1) || t2.P2 != parser.Vertex(3) || t2.P3 != parser.Vertex(4) {
+		t.Errorf("Error: %v", t2)
+	}
+}
+
+func TestObjVertexNormals(t *testing.T) {
+	/* Scenario: Vertex normal records
+	   Given file ← a file containing:
+	     """
+	     vn 0 0 1
+	     vn 0.707 0 -0.707
+	     vn 1 2 3
+	     """
+	   When parser ← parse_obj_file(file)
+	   Then parser.normals[1] = vector(0, 0, 1)
+	     And parser.normals[2] = vector(0.707, 0, -0.707)
+	     And parser.normals[3] = vector(1, 2, 3) */
+	file := `vn 0 0 1
+vn 0.707 0 -0.707
+vn 1 2 3`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	expected := []rt.Tuple{
+		rt.NewVector(0, 0, 1),
+		rt.NewVector(0.707, 0, -0.707),
+		rt.NewVector(1, 2, 3),
+	}
+	for idx, normal := range expected {
+		if !parser.Normal(idx + 1).Equals(normal) {
+			t.Errorf("Error: %v", parser.Normal(idx+1))
+		}
+	}
+}
+
+func TestObjFacesWithNormals(t *testing.T) {
+	/* Scenario: Faces with normals
+	   Given file ← a file containing:
+	     """
+	     v 0 1 0
+	     v -1 0 0
+	     v 1 0 0
+
+	     vn -1 0 0
+	     vn 1 0 0
+	     vn 0 1 0
+
+	     f 1//3 2//1 3//2
+	     f 1/0/3 2/102/1 3/14/2
+	     """
+	   When parser ← parse_obj_file(file)
+	     And g ← parser.default_group
+	     And t1 ← first child of g
+	     And t2 ← second child of g
+	   Then t1.p1 = parser.vertices[1]
+	     And t1.p2 = parser.vertices[2]
+	     And t1.p3 = parser.vertices[3]
+	     And t1.n1 = parser.normals[3]
+	     And t1.n2 = parser.normals[1]
+	     And t1.n3 = parser.normals[2]
+	     And t2 = t1 */
+	file := `v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+f 1//3 2//1 3//2
+f 1/0/3 2/102/1 3/14/2`
+
+	parser, err := rt.ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	g := parser.DefaultGroup
+	if len(g) != 2 {
+		t.Fatalf("Error: %v", len(g))
+	}
+
+	for _, child := range g {
+		tri := child.(*rt.SmoothTriangle)
+		if tri.P1 != parser.Vertex(1) || tri.P2 != parser.Vertex(2) || tri.P3 != parser.Vertex(3) {
+			t.Errorf("Error: %v", tri)
+		}
+
+		if tri.N1 != parser.Normal(3) || tri.N2 != parser.Normal(1) || tri.N3 != parser.Normal(2) {
+			t.Errorf("Error: %v", tri)
+		}
+	}
+}
+
+func TestObjInvalidFace(t *testing.T) {
+	file := `v 0 1 0
+v -1 0 0
+
+f 1 2 3`
+
+	_, err := rt.ParseObjFile(strings.NewReader(file))
+
+	if err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("Error: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package raytracer
+
+import "math"
+
+type Pattern interface {
+	PatternAt(p Tuple) *Color
+	Sample(p Tuple) *Color
+	PatternAtShape(object Shape, worldPoint Tuple) *Color
+	GetTransform() Matrix
+	SetTransform(transform Matrix)
+}
+
+// pattern holds the transform shared by all patterns, delegating the color
+// lookup in pattern space to the concrete pattern in local.
+type pattern struct {
+	Transform Matrix
+
+	inverse Matrix
+	local   Pattern
+}
+
+func newPattern(local Pattern) pattern {
+	return pattern{
+		Transform: Identity(),
+		inverse:   Identity(),
+		local:     local,
+	}
+}
+
+func (p *pattern) GetTransform() Matrix {
+	return p.Transform
+}
+
+func (p *pattern) SetTransform(transform Matrix) {
+	p.Transform = transform
+	p.inverse = transform.Inv()
+}
+
+// Sample looks up the color at a point given in the space the pattern is
+// placed in, which is object space for a material's pattern or the parent
+// pattern's space for nested patterns.
+func (p *pattern) Sample(point Tuple) *Color {
+	return p.local.PatternAt(p.inverse.MulT(point))
+}
+
+func (p *pattern) PatternAtShape(object Shape, worldPoint Tuple) *Color {
+	return p.Sample(object.WorldToObject(worldPoint))
+}
+
+type SolidPattern struct {
+	pattern
+	Color *Color
+}
+
+func NewSolidPattern(color *Color) *SolidPattern {
+	p := &SolidPattern{Color: color}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *SolidPattern) PatternAt(point Tuple) *Color {
+	return p.Color
+}
+
+type StripePattern struct {
+	pattern
+	A, B Pattern
+}
+
+func NewStripePattern(a, b Pattern) *StripePattern {
+	p := &StripePattern{A: a, B: b}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *StripePattern) PatternAt(point Tuple) *Color {
+	if int(math.Floor(point.X))%2 == 0 {
+		return p.A.Sample(point)
+	}
+	return p.B.Sample(point)
+}
+
+type GradientPattern struct {
+	pattern
+	A, B Pattern
+}
+
+func NewGradientPattern(a, b Pattern) *GradientPattern {
+	p := &GradientPattern{A: a, B: b}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *GradientPattern) PatternAt(point Tuple) *Color {
+	a := p.A.Sample(point)
+	distance := p.B.Sample(point).Sub(a)
+	fraction := point.X - math.Floor(point.X)
+
+	return a.Add(distance.Mul(fraction))
+}
+
+type RingPattern struct {
+	pattern
+	A, B Pattern
+}
+
+func NewRingPattern(a, b Pattern) *RingPattern {
+	p := &RingPattern{A: a, B: b}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *RingPattern) PatternAt(point Tuple) *Color {
+	distance := math.Sqrt(math.Pow(point.X, 2) + math.Pow(point.Z, 2))
+
+	if int(math.Floor(distance))%2 == 0 {
+		return p.A.Sample(point)
+	}
+	return p.B.Sample(point)
+}
+
+type CheckersPattern struct {
+	pattern
+	A, B Pattern
+}
+
+func NewCheckersPattern(a, b Pattern) *CheckersPattern {
+	p := &CheckersPattern{A: a, B: b}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *CheckersPattern) PatternAt(point Tuple) *Color {
+	sum := math.Floor(point.X) + math.Floor(point.Y) + math.Floor(point.Z)
+
+	if int(sum)%2 == 0 {
+		return p.A.Sample(point)
+	}
+	return p.B.Sample(point)
+}
+
+type BlendedPattern struct {
+	pattern
+	A, B Pattern
+}
+
+func NewBlendedPattern(a, b Pattern) *BlendedPattern {
+	p := &BlendedPattern{A: a, B: b}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *BlendedPattern) PatternAt(point Tuple) *Color {
+	return p.A.Sample(point).Add(p.B.Sample(point)).Mul(0.5)
+}
+
+// PerturbedPattern jitters the lookup point of Pattern with Perlin noise,
+// moving it by up to Scale along each axis.
+type PerturbedPattern struct {
+	pattern
+	Pattern Pattern
+	Scale   float64
+
+	noise *Perlin
+}
+
+func NewPerturbedPattern(pattern Pattern, scale float64, seed int64) *PerturbedPattern {
+	p := &PerturbedPattern{Pattern: pattern, Scale: scale, noise: NewPerlin(seed)}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *PerturbedPattern) PatternAt(point Tuple) *Color {
+	// offset the lookups for y and z so the axes don't move in lockstep
+	jitter := NewVector(
+		p.noise.Noise(point.X, point.Y, point.Z),
+		p.noise.Noise(point.X+31.416, point.Y+27.183, point.Z+14.142),
+		p.noise.Noise(point.X-17.32, point.Y-22.36, point.Z-41.421),
+	)
+
+	return p.Pattern.Sample(point.Add(jitter.Mul(p.Scale)))
+}
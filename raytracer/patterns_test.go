@@ -0,0 +1,387 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+var (
+	black = &rt.Color{0, 0, 0}
+	white = &rt.Color{1, 1, 1}
+)
+
+func TestNewStripePattern(t *testing.T) {
+	/* Scenario: Creating a stripe pattern
+	   Given pattern ← stripe_pattern(white, black)
+	   Then pattern.a = white
+	     And pattern.b = black */
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	if pattern.A.(*rt.SolidPattern).Color != white {
+		t.Errorf("Error: %v", pattern.A)
+	}
+
+	if pattern.B.(*rt.SolidPattern).Color != black {
+		t.Errorf("Error: %v", pattern.B)
+	}
+}
+
+func TestStripePatternConstantInY(t *testing.T) {
+	/* Scenario: A stripe pattern is constant in y
+	   Given pattern ← stripe_pattern(white, black)
+	   Then stripe_at(pattern, point(0, 0, 0)) = white
+	     And stripe_at(pattern, point(0, 1, 0)) = white
+	     And stripe_at(pattern, point(0, 2, 0)) = white */
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	for _, p := range []rt.Tuple{rt.NewPoint(0, 0, 0), rt.NewPoint(0, 1, 0), rt.NewPoint(0, 2, 0)} {
+		if !pattern.PatternAt(p).Equals(white) {
+			t.Errorf("Error: %v", p)
+		}
+	}
+}
+
+func TestStripePatternConstantInZ(t *testing.T) {
+	/* Scenario: A stripe pattern is constant in z
+	   Given pattern ← stripe_pattern(white, black)
+	   Then stripe_at(pattern, point(0, 0, 0)) = white
+	     And stripe_at(pattern, point(0, 0, 1)) = white
+	     And stripe_at(pattern, point(0, 0, 2)) = white */
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	for _, p := range []rt.Tuple{rt.NewPoint(0, 0, 0), rt.NewPoint(0, 0, 1), rt.NewPoint(0, 0, 2)} {
+		if !pattern.PatternAt(p).Equals(white) {
+			t.Errorf("Error: %v", p)
+		}
+	}
+}
+
+func TestStripePatternAlternatesInX(t *testing.T) {
+	/* Scenario: A stripe pattern alternates in x
+	   Given pattern ← stripe_pattern(white, black)
+	   Then stripe_at(pattern, point(0, 0, 0)) = white
+	     And stripe_at(pattern, point(0.9, 0, 0)) = white
+	     And stripe_at(pattern, point(1, 0, 0)) = black
+	     And stripe_at(pattern, point(-0.1, 0, 0)) = black
+	     And stripe_at(pattern, point(-1, 0, 0)) = black
+	     And stripe_at(pattern, point(-1.1, 0, 0)) = white */
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(0, 0, 0), white},
+		{rt.NewPoint(0.9, 0, 0), white},
+		{rt.NewPoint(1, 0, 0), black},
+		{rt.NewPoint(-0.1, 0, 0), black},
+		{rt.NewPoint(-1, 0, 0), black},
+		{rt.NewPoint(-1.1, 0, 0), white},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
+
+func TestStripesWithObjectTransformation(t *testing.T) {
+	/* Scenario: Stripes with an object transformation
+	   Given object ← sphere()
+	     And set_transform(object, scaling(2, 2, 2))
+	     And pattern ← stripe_pattern(white, black)
+	   When c ← stripe_at_object(pattern, object, point(1.5, 0, 0))
+	   Then c = white */
+	object := rt.NewSphere()
+	object.SetTransform(rt.Scaling(2, 2, 2))
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	c := pattern.PatternAtShape(object, rt.NewPoint(1.5, 0, 0))
+
+	if !c.Equals(white) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestStripesWithPatternTransformation(t *testing.T) {
+	/* Scenario: Stripes with a pattern transformation
+	   Given object ← sphere()
+	     And pattern ← stripe_pattern(white, black)
+	     And set_pattern_transform(pattern, scaling(2, 2, 2))
+	   When c ← stripe_at_object(pattern, object, point(1.5, 0, 0))
+	   Then c = white */
+	object := rt.NewSphere()
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	pattern.SetTransform(rt.Scaling(2, 2, 2))
+
+	c := pattern.PatternAtShape(object, rt.NewPoint(1.5, 0, 0))
+
+	if !c.Equals(white) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestStripesWithObjectAndPatternTransformation(t *testing.T) {
+	/* Scenario: Stripes with both an object and a pattern transformation
+	   Given object ← sphere()
+	     And set_transform(object, scaling(2, 2, 2))
+	     And pattern ← stripe_pattern(white, black)
+	     And set_pattern_transform(pattern, translation(0.5, 0, 0))
+	   When c ← stripe_at_object(pattern, object, point(2.5, 0, 0))
+	   Then c = white */
+	object := rt.NewSphere()
+	object.SetTransform(rt.Scaling(2, 2, 2))
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	pattern.SetTransform(rt.Translation(0.5, 0, 0))
+
+	c := pattern.PatternAtShape(object, rt.NewPoint(2.5, 0, 0))
+
+	if !c.Equals(white) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestPatternDefaultTransformation(t *testing.T) {
+	/* Scenario: The default pattern transformation
+	   Given pattern ← test_pattern()
+	   Then pattern.transform = identity_matrix */
+	var pattern rt.Pattern = rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	if !pattern.GetTransform().Equals(rt.Identity()) {
+		t.Errorf("Error: %v", pattern.GetTransform())
+	}
+}
+
+func TestPatternAssignTransformation(t *testing.T) {
+	/* Scenario: Assigning a transformation
+	   Given pattern ← test_pattern()
+	   When set_pattern_transform(pattern, translation(1, 2, 3))
+	   Then pattern.transform = translation(1, 2, 3) */
+	var pattern rt.Pattern = rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	pattern.SetTransform(rt.Translation(1, 2, 3))
+
+	if !pattern.GetTransform().Equals(rt.Translation(1, 2, 3)) {
+		t.Errorf("Error: %v", pattern.GetTransform())
+	}
+}
+
+func TestPatternInGroupedShape(t *testing.T) {
+	/* Scenario: A pattern on a child object honours the parent transforms
+	   Given g ← group() with:
+	       | transform | scaling(2, 2, 2) |
+	     And object ← sphere() with:
+	       | transform | translation(0.5, 0, 0) |
+	     And object is added to g
+	     And pattern ← stripe_pattern(white, black)
+	   When c ← pattern_at_shape(pattern, object, point(2.5, 0, 0))
+	   Then c = white */
+	g := rt.NewGroup()
+	g.SetTransform(rt.Scaling(2, 2, 2))
+	object := rt.NewSphere()
+	object.SetTransform(rt.Translation(0.5, 0, 0))
+	g.AddChild(object)
+	pattern := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	c := pattern.PatternAtShape(object, rt.NewPoint(2.5, 0, 0))
+
+	if !c.Equals(white) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestGradientPattern(t *testing.T) {
+	/* Scenario: A gradient linearly interpolates between colors
+	   Given pattern ← gradient_pattern(white, black)
+	   Then pattern_at(pattern, point(0, 0, 0)) = white
+	     And pattern_at(pattern, point(0.25, 0, 0)) = color(0.75, 0.75, 0.75)
+	     And pattern_at(pattern, point(0.5, 0, 0)) = color(0.5, 0.5, 0.5)
+	     And pattern_at(pattern, point(0.75, 0, 0)) = color(0.25, 0.25, 0.25) */
+	pattern := rt.NewGradientPattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(0, 0, 0), white},
+		{rt.NewPoint(0.25, 0, 0), &rt.Color{0.75, 0.75, 0.75}},
+		{rt.NewPoint(0.5, 0, 0), &rt.Color{0.5, 0.5, 0.5}},
+		{rt.NewPoint(0.75, 0, 0), &rt.Color{0.25, 0.25, 0.25}},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
+
+func TestRingPattern(t *testing.T) {
+	/* Scenario: A ring should extend in both x and z
+	   Given pattern ← ring_pattern(white, black)
+	   Then pattern_at(pattern, point(0, 0, 0)) = white
+	     And pattern_at(pattern, point(1, 0, 0)) = black
+	     And pattern_at(pattern, point(0, 0, 1)) = black
+	     # 0.708 = just slightly more than √2/2
+	     And pattern_at(pattern, point(0.708, 0, 0.708)) = black */
+	pattern := rt.NewRingPattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(0, 0, 0), white},
+		{rt.NewPoint(1, 0, 0), black},
+		{rt.NewPoint(0, 0, 1), black},
+		{rt.NewPoint(0.708, 0, 0.708), black},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
+
+func TestCheckersPattern(t *testing.T) {
+	/* Scenario: Checkers should repeat in x, y and z
+	   Given pattern ← checkers_pattern(white, black)
+	   Then pattern_at(pattern, point(0, 0, 0)) = white
+	     And pattern_at(pattern, point(0.99, 0, 0)) = white
+	     And pattern_at(pattern, point(1.01, 0, 0)) = black
+	     And pattern_at(pattern, point(0, 0.99, 0)) = white
+	     And pattern_at(pattern, point(0, 1.01, 0)) = black
+	     And pattern_at(pattern, point(0, 0, 0.99)) = white
+	     And pattern_at(pattern, point(0, 0, 1.01)) = black */
+	pattern := rt.NewCheckersPattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(0, 0, 0), white},
+		{rt.NewPoint(0.99, 0, 0), white},
+		{rt.NewPoint(1.01, 0, 0), black},
+		{rt.NewPoint(0, 0.99, 0), white},
+		{rt.NewPoint(0, 1.01, 0), black},
+		{rt.NewPoint(0, 0, 0.99), white},
+		{rt.NewPoint(0, 0, 1.01), black},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
+
+func TestNestedPattern(t *testing.T) {
+	/* Scenario: Checkers whose squares are themselves stripes
+	   Given a ← stripe_pattern(white, black)
+	     And b ← stripe_pattern(black, white)
+	     And set_pattern_transform(b, scaling(0.5, 0.5, 0.5))
+	     And pattern ← checkers_pattern(a, b)
+	   Then pattern_at(pattern, point(0.5, 0, 0)) = white
+	     And pattern_at(pattern, point(1.25, 0, 0)) = black
+	     And pattern_at(pattern, point(1.75, 0, 0)) = white */
+	a := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	b := rt.NewStripePattern(rt.NewSolidPattern(black), rt.NewSolidPattern(white))
+	b.SetTransform(rt.Scaling(0.5, 0.5, 0.5))
+	pattern := rt.NewCheckersPattern(a, b)
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(0.5, 0, 0), white},
+		{rt.NewPoint(1.25, 0, 0), black},
+		{rt.NewPoint(1.75, 0, 0), white},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
+
+func TestBlendedPattern(t *testing.T) {
+	/* Scenario: A blended pattern averages its two patterns
+	   Given a ← stripe_pattern(white, black)
+	     And b ← stripe_pattern(white, black)
+	     And set_pattern_transform(b, rotation_y(π/2))
+	     And pattern ← blended_pattern(a, b)
+	   Then pattern_at(pattern, point(0.5, 0, -0.5)) = white
+	     And pattern_at(pattern, point(1.5, 0, -0.5)) = color(0.5, 0.5, 0.5)
+	     And pattern_at(pattern, point(1.5, 0, 0.5)) = black */
+	a := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	b := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	b.SetTransform(rt.RotationY(math.Pi / 2))
+	pattern := rt.NewBlendedPattern(a, b)
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(0.5, 0, -0.5), white},
+		{rt.NewPoint(1.5, 0, -0.5), &rt.Color{0.5, 0.5, 0.5}},
+		{rt.NewPoint(1.5, 0, 0.5), black},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
+
+func TestPerturbedPatternDeterministic(t *testing.T) {
+	/* Scenario: Perturbed patterns with the same seed agree */
+	stripes := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	p1 := rt.NewPerturbedPattern(stripes, 0.5, 42)
+	p2 := rt.NewPerturbedPattern(stripes, 0.5, 42)
+
+	for x := -2.0; x < 2; x += 0.1 {
+		point := rt.NewPoint(x, 0.3, 0.7)
+
+		if !p1.PatternAt(point).Equals(p2.PatternAt(point)) {
+			t.Errorf("Error: %v", point)
+		}
+	}
+}
+
+func TestPerturbedPatternJittersPoint(t *testing.T) {
+	/* Scenario: A perturbed pattern moves the stripe boundaries */
+	stripes := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	perturbed := rt.NewPerturbedPattern(stripes, 0.5, 7)
+
+	differs := false
+	for x := -2.0; x < 2; x += 0.05 {
+		point := rt.NewPoint(x, 0.3, 0.7)
+
+		if !perturbed.PatternAt(point).Equals(stripes.PatternAt(point)) {
+			differs = true
+		}
+	}
+
+	if !differs {
+		t.Errorf("Error: %v", differs)
+	}
+}
+
+func TestPerturbedPatternZeroScale(t *testing.T) {
+	/* Scenario: A perturbed pattern with no scale matches its pattern */
+	stripes := rt.NewStripePattern(rt.NewSolidPattern(white), rt.NewSolidPattern(black))
+	perturbed := rt.NewPerturbedPattern(stripes, 0, 7)
+
+	for x := -2.0; x < 2; x += 0.05 {
+		point := rt.NewPoint(x, 0.3, 0.7)
+
+		if !perturbed.PatternAt(point).Equals(stripes.PatternAt(point)) {
+			t.Errorf("Error: %v", point)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package raytracer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// EncodePFM writes c as a little endian color Portable Float Map, storing
+// every channel as a 32 bit float.
+func EncodePFM(w io.Writer, c *Canvas) error {
+	if _, err := fmt.Fprintf(w, "PF\n%d %d\n-1.0\n", c.Width, c.Height); err != nil {
+		return err
+	}
+
+	row := make([]byte, c.Width*12)
+	// Rows are stored from the bottom up.
+	for y := c.Height - 1; y >= 0; y-- {
+		for x := 0; x < c.Width; x++ {
+			color := c.GetAt(x, y)
+			binary.LittleEndian.PutUint32(row[x*12:], math.Float32bits(float32(color.R)))
+			binary.LittleEndian.PutUint32(row[x*12+4:], math.Float32bits(float32(color.G)))
+			binary.LittleEndian.PutUint32(row[x*12+8:], math.Float32bits(float32(color.B)))
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodePFM reads a color (PF) or grayscale (Pf) Portable Float Map in
+// either byte order.
+func DecodePFM(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	var kind string
+	var width, height int
+	var scale float64
+	if _, err := fmt.Fscan(br, &kind, &width, &height, &scale); err != nil {
+		return nil, fmt.Errorf("pfm: invalid header: %v", err)
+	}
+	// Exactly one whitespace character separates the header from the data.
+	if _, err := br.ReadByte(); err != nil {
+		return nil, fmt.Errorf("pfm: invalid header: %v", err)
+	}
+
+	channels := 0
+	switch kind {
+	case "PF":
+		channels = 3
+	case "Pf":
+		channels = 1
+	default:
+		return nil, fmt.Errorf("pfm: unknown type %q", kind)
+	}
+	if !validDecodedSize(int64(width), int64(height), channels) || scale == 0 {
+		return nil, fmt.Errorf("pfm: invalid size %dx%d or scale %v", width, height, scale)
+	}
+
+	var order binary.ByteOrder = binary.BigEndian
+	if scale < 0 {
+		order = binary.LittleEndian
+	}
+
+	c := NewCanvas(width, height)
+	row := make([]byte, width*channels*4)
+	value := func(idx int) float64 {
+		return float64(math.Float32frombits(order.Uint32(row[idx*4:])))
+	}
+
+	for y := height - 1; y >= 0; y-- {
+		if _, err := io.ReadFull(br, row); err != nil {
+			return nil, fmt.Errorf("pfm: reading pixels: %v", err)
+		}
+		for x := 0; x < width; x++ {
+			if channels == 1 {
+				v := value(x)
+				c.SetAt(x, y, &Color{v, v, v})
+			} else {
+				c.SetAt(x, y, &Color{value(x * 3), value(x*3 + 1), value(x*3 + 2)})
+			}
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,34 @@
+package raytracer
+
+import "math"
+
+type Plane struct {
+	shape
+}
+
+func NewPlane() *Plane {
+	p := &Plane{}
+	p.shape = newShape(p)
+	return p
+}
+
+func (p *Plane) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	if math.Abs(r.Direction.Y) < epsilon {
+		return xs
+	}
+
+	t := -r.Origin.Y / r.Direction.Y
+
+	return append(xs, NewIntersection(t, p))
+}
+
+func (p *Plane) LocalNormalAt(point Tuple) Tuple {
+	return NewVector(0, 1, 0)
+}
+
+func (p *Plane) Bounds() *BoundingBox {
+	return NewBoundingBox(
+		NewPoint(math.Inf(-1), 0, math.Inf(-1)),
+		NewPoint(math.Inf(1), 0, math.Inf(1)),
+	)
+}
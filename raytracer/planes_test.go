@@ -0,0 +1,113 @@
+package raytracer_test
+
+import (
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestPlaneNormalIsConstant(t *testing.T) {
+	/* Scenario: The normal of a plane is constant everywhere
+	   Given p ← plane()
+	   When n1 ← local_normal_at(p, point(0, 0, 0))
+	     And n2 ← local_normal_at(p, point(10, 0, -10))
+	     And n3 ← local_normal_at(p, point(-5, 0, 150))
+	   Then n1 = vector(0, 1, 0)
+	     And n2 = vector(0, 1, 0)
+	     And n3 = vector(0, 1, 0) */
+	p := rt.NewPlane()
+
+	n1 := p.LocalNormalAt(rt.NewPoint(0, 0, 0))
+	n2 := p.LocalNormalAt(rt.NewPoint(10, 0, -10))
+	n3 := p.LocalNormalAt(rt.NewPoint(-5, 0, 150))
+
+	for _, n := range []rt.Tuple{n1, n2, n3} {
+		if !n.Equals(rt.NewVector(0, 1, 0)) {
+			t.Errorf("Error: %v", n)
+		}
+	}
+}
+
+func TestPlaneIntersectParallel(t *testing.T) {
+	/* Scenario: Intersect with a ray parallel to the plane
+	   Given p ← plane()
+	     And r ← ray(point(0, 10, 0), vector(0, 0, 1))
+	   When xs ← local_intersect(p, r)
+	   Then xs is empty */
+	p := rt.NewPlane()
+	r := rt.NewRay(rt.NewPoint(0, 10, 0), rt.NewVector(0, 0, 1))
+
+	xs := p.LocalIntersect(r)
+
+	if len(xs) != 0 {
+		t.Errorf("Error: %v", len(xs))
+	}
+}
+
+func TestPlaneIntersectCoplanar(t *testing.T) {
+	/* Scenario: Intersect with a coplanar ray
+	   Given p ← plane()
+	     And r ← ray(point(0, 0, 0), vector(0, 0, 1))
+	   When xs ← local_intersect(p, r)
+	   Then xs is empty */
+	p := rt.NewPlane()
+	r := rt.NewRay(rt.NewPoint(0, 0, 0), rt.NewVector(0, 0, 1))
+
+	xs := p.LocalIntersect(r)
+
+	if len(xs) != 0 {
+		t.Errorf("Error: %v", len(xs))
+	}
+}
+
+func TestPlaneIntersectFromAbove(t *testing.T) {
+	/* Scenario: A ray intersecting a plane from above
+	   Given p ← plane()
+	     And r ← ray(point(0, 1, 0), vector(0, -1, 0))
+	   When xs ← local_intersect(p, r)
+	   Then xs.count = 1
+	     And xs[0].t = 1
+	     And xs[0].object = p */
+	p := rt.NewPlane()
+	r := rt.NewRay(rt.NewPoint(0, 1, 0), rt.NewVector(0, -1, 0))
+
+	xs := p.LocalIntersect(r)
+
+	if len(xs) != 1 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	if xs[0].T != 1 {
+		t.Errorf("Error: %v", xs[0].T)
+	}
+
+	if xs[0].Object != p {
+		t.Errorf("Error: %v", xs[0].Object)
+	}
+}
+
+func TestPlaneIntersectFromBelow(t *testing.T) {
+	/* Scenario: A ray intersecting a plane from below
+	   Given p ← plane()
+	     And r ← ray(point(0, -1, 0), vector(0, 1, 0))
+	   When xs ← local_intersect(p, r)
+	   Then xs.count = 1
+	     And xs[0].t = 1
+	     And xs[0].object = p */
+	p := rt.NewPlane()
+	r := rt.NewRay(rt.NewPoint(0, -1, 0), rt.NewVector(0, 1, 0))
+
+	xs := p.LocalIntersect(r)
+
+	if len(xs) != 1 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	if xs[0].T != 1 {
+		t.Errorf("Error: %v", xs[0].T)
+	}
+
+	if xs[0].Object != p {
+		t.Errorf("Error: %v", xs[0].Object)
+	}
+}
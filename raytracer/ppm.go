@@ -0,0 +1,216 @@
+package raytracer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ppmLineLength is the longest line plain Netpbm files may contain.
+const ppmLineLength = 70
+
+// ToPPM returns the canvas as a plain text (P3) PPM image.
+func (c *Canvas) ToPPM() string {
+	var sb strings.Builder
+	EncodePlainPPM(&sb, c)
+	return sb.String()
+}
+
+// EncodePlainPPM writes c as a plain text (P3) PPM image, going through
+// Display without a transfer curve by default. Every row starts on a new line and lines are wrapped before they
+// get longer than 70 characters.
+func EncodePlainPPM(w io.Writer, c *Canvas) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P3\n%d %d\n255\n", c.Width, c.Height)
+
+	line := make([]byte, 0, ppmLineLength)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			color := c.Display.apply(c.GetAt(x, y), LinearTransfer)
+			for _, v := range []float64{color.R, color.G, color.B} {
+				value := strconv.Itoa(int(to8Bit(v)))
+				if len(line) > 0 && len(line)+1+len(value) > ppmLineLength {
+					bw.Write(append(line, '\n'))
+					line = line[:0]
+				}
+				if len(line) > 0 {
+					line = append(line, ' ')
+				}
+				line = append(line, value...)
+			}
+		}
+		bw.Write(append(line, '\n'))
+		line = line[:0]
+	}
+
+	return bw.Flush()
+}
+
+// EncodePPM writes c as a binary (P6) PPM image, going through Display
+// without a transfer curve by default.
+func EncodePPM(w io.Writer, c *Canvas) error {
+	if _, err := fmt.Fprintf(w, "P6\n%d %d\n255\n", c.Width, c.Height); err != nil {
+		return err
+	}
+
+	row := make([]byte, c.Width*3)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			color := c.Display.apply(c.GetAt(x, y), LinearTransfer)
+			row[x*3] = to8Bit(color.R)
+			row[x*3+1] = to8Bit(color.G)
+			row[x*3+2] = to8Bit(color.B)
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncodePGM writes the luminance of c as a binary (P5) PGM image, going
+// through Display without a transfer curve by default. The luminance is
+// taken from the linear colors, before any transfer curve.
+func EncodePGM(w io.Writer, c *Canvas) error {
+	if _, err := fmt.Fprintf(w, "P5\n%d %d\n255\n", c.Width, c.Height); err != nil {
+		return err
+	}
+
+	row := make([]byte, c.Width)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			gray := luminance(c.GetAt(x, y))
+			row[x] = to8Bit(c.Display.apply(&Color{gray, gray, gray}, LinearTransfer).R)
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ppmReader reads the whitespace separated parts of a Netpbm file, skipping
+// comments.
+type ppmReader struct {
+	*bufio.Reader
+}
+
+func (r ppmReader) int() (int, error) {
+	var token []byte
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF && len(token) > 0 {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case b == '#' && len(token) == 0:
+			if _, err := r.ReadString('\n'); err != nil {
+				return 0, err
+			}
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+			if len(token) > 0 {
+				return strconv.Atoi(string(token))
+			}
+		default:
+			token = append(token, b)
+		}
+	}
+
+	return strconv.Atoi(string(token))
+}
+
+// DecodePPM reads a PPM (P3 or P6) or PGM (P2 or P5) image, scaling its
+// values to [0, 1] without undoing any transfer curve, the inverse of the
+// default Display.
+func DecodePPM(r io.Reader) (*Canvas, error) {
+	pr := ppmReader{bufio.NewReader(r)}
+
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(pr, magic); err != nil {
+		return nil, fmt.Errorf("ppm: %v", err)
+	}
+
+	var channels int
+	var plain bool
+	switch string(magic) {
+	case "P2":
+		channels, plain = 1, true
+	case "P3":
+		channels, plain = 3, true
+	case "P5":
+		channels = 1
+	case "P6":
+		channels = 3
+	default:
+		return nil, fmt.Errorf("ppm: unknown type %q", magic)
+	}
+
+	var header [3]int
+	for i := range header {
+		var err error
+		if header[i], err = pr.int(); err != nil {
+			return nil, fmt.Errorf("ppm: invalid header: %v", err)
+		}
+	}
+	width, height, maxval := header[0], header[1], header[2]
+	if width <= 0 || height <= 0 || maxval <= 0 || maxval > 0xffff {
+		return nil, fmt.Errorf("ppm: invalid size %dx%d or maximum value %d", width, height, maxval)
+	}
+
+	values := make([]int, width*height*channels)
+	if plain {
+		for i := range values {
+			v, err := pr.int()
+			if err != nil {
+				return nil, fmt.Errorf("ppm: reading pixels: %v", err)
+			}
+			values[i] = v
+		}
+	} else {
+		size := 1
+		if maxval > 0xff {
+			size = 2
+		}
+		data := make([]byte, len(values)*size)
+		if _, err := io.ReadFull(pr, data); err != nil {
+			return nil, fmt.Errorf("ppm: reading pixels: %v", err)
+		}
+		for i := range values {
+			if size == 2 {
+				values[i] = int(data[i*2])<<8 | int(data[i*2+1])
+			} else {
+				values[i] = int(data[i])
+			}
+		}
+	}
+
+	c := NewCanvas(width, height)
+	for i, v := range values {
+		if v < 0 || v > maxval {
+			return nil, fmt.Errorf("ppm: value %d out of range", v)
+		}
+
+		f := float64(v) / float64(maxval)
+		pixel := &c.pixels[i/channels]
+		switch {
+		case channels == 1:
+			*pixel = Color{f, f, f}
+		case i%3 == 0:
+			pixel.R = f
+		case i%3 == 1:
+			pixel.G = f
+		default:
+			pixel.B = f
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,255 @@
+package raytracer_test
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestPPMHeader(t *testing.T) {
+	/* Scenario: Constructing the PPM header
+	   Given c ← canvas(5, 3)
+	   When ppm ← canvas_to_ppm(c)
+	   Then lines 1-3 of ppm are
+	     """
+	     P3
+	     5 3
+	     255
+	     """ */
+	c := rt.NewCanvas(5, 3)
+	ppm := c.ToPPM()
+
+	if lines := strings.Split(ppm, "\n"); strings.Join(lines[:3], "\n") != "P3\n5 3\n255" {
+		t.Errorf("Error: %v", lines[:3])
+	}
+}
+
+func TestPPMPixelData(t *testing.T) {
+	/* Scenario: Constructing the PPM pixel data
+	   Given c ← canvas(5, 3)
+	     And c1 ← color(1.5, 0, 0)
+	     And c2 ← color(0, 0.5, 0)
+	     And c3 ← color(-0.5, 0, 1)
+	   When write_pixel(c, 0, 0, c1)
+	     And write_pixel(c, 2, 1, c2)
+	     And write_pixel(c, 4, 2, c3)
+	     And ppm ← canvas_to_ppm(c)
+	   Then lines 4-6 of ppm are
+	     """
+	     255 0 0 0 0 0 0 0 0 0 0 0 0 0 0
+	     0 0 0 0 0 0 0 128 0 0 0 0 0 0 0
+	     0 0 0 0 0 0 0 0 0 0 0 0 0 0 255
+	     """ */
+	c := rt.NewCanvas(5, 3)
+	c.SetAt(0, 0, &rt.Color{1.5, 0, 0})
+	c.SetAt(2, 1, &rt.Color{0, 0.5, 0})
+	c.SetAt(4, 2, &rt.Color{-0.5, 0, 1})
+
+	expected := "255 0 0 0 0 0 0 0 0 0 0 0 0 0 0\n" +
+		"0 0 0 0 0 0 0 128 0 0 0 0 0 0 0\n" +
+		"0 0 0 0 0 0 0 0 0 0 0 0 0 0 255"
+
+	if lines := strings.Split(c.ToPPM(), "\n"); strings.Join(lines[3:6], "\n") != expected {
+		t.Errorf("Error: %v", lines[3:6])
+	}
+}
+
+func TestPPMSplittingLongLines(t *testing.T) {
+	/* Scenario: Splitting long lines in PPM files
+	   Given c ← canvas(10, 2)
+	   When every pixel of c is set to color(1, 0.8, 0.6)
+	     And ppm ← canvas_to_ppm(c)
+	   Then lines 4-7 of ppm are
+	     """
+	     255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204
+	     153 255 204 153 255 204 153 255 204 153 255 204 153
+	     255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204
+	     153 255 204 153 255 204 153 255 204 153 255 204 153
+	     """ */
+	c := rt.NewCanvas(10, 2)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 10; x++ {
+			c.SetAt(x, y, &rt.Color{1, 0.8, 0.6})
+		}
+	}
+
+	expected := "255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n" +
+		"153 255 204 153 255 204 153 255 204 153 255 204 153\n" +
+		"255 204 153 255 204 153 255 204 153 255 204 153 255 204 153 255 204\n" +
+		"153 255 204 153 255 204 153 255 204 153 255 204 153"
+
+	lines := strings.Split(c.ToPPM(), "\n")
+	if strings.Join(lines[3:7], "\n") != expected {
+		t.Errorf("Error: %v", lines[3:7])
+	}
+
+	for _, line := range lines {
+		if len(line) > 70 {
+			t.Errorf("Error: %v", line)
+		}
+	}
+}
+
+func TestPPMEndsWithNewline(t *testing.T) {
+	/* Scenario: PPM files are terminated by a newline character
+	   Given c ← canvas(5, 3)
+	   When ppm ← canvas_to_ppm(c)
+	   Then ppm ends with a newline character */
+	c := rt.NewCanvas(5, 3)
+
+	if ppm := c.ToPPM(); !strings.HasSuffix(ppm, "\n") {
+		t.Errorf("Error: %q", ppm)
+	}
+}
+
+func TestPPMDisplay(t *testing.T) {
+	/* Scenario: Writing a PPM applies the exposure and clamps the colors
+	   Given c ← canvas(1, 1) with color(0.25, 0.5, 2)
+	     And the display exposure of c is 1 stop
+	   When ppm ← canvas_to_ppm(c)
+	   Then the pixel of ppm is "128 255 255" */
+	c := rt.NewCanvas(1, 1)
+	c.SetAt(0, 0, &rt.Color{0.25, 0.5, 2})
+	c.Display.Exposure = 1
+
+	if ppm := c.ToPPM(); ppm != "P3\n1 1\n255\n128 255 255\n" {
+		t.Errorf("Error: %q", ppm)
+	}
+}
+
+func TestPPMMatchesPNG(t *testing.T) {
+	/* Values halfway between two 8 bit levels round the same way in every
+	   format */
+	c := rt.NewCanvas(16, 1)
+	for x := 0; x < c.Width; x++ {
+		v := (float64(x*16) + 0.5) / 255
+		c.SetAt(x, 0, &rt.Color{v, v, 1 - v})
+	}
+	c.Display.Transfer = rt.SRGBTransfer
+
+	var ppm, pngData bytes.Buffer
+	c.Encode(&ppm, "ppm")
+	c.Encode(&pngData, "png")
+
+	img, err := png.Decode(&pngData)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	pixels := ppm.Bytes()[len("P6\n16 1\n255\n"):]
+	for x := 0; x < c.Width; x++ {
+		r, g, b, _ := img.At(x, 0).RGBA()
+		if byte(r>>8) != pixels[x*3] || byte(g>>8) != pixels[x*3+1] || byte(b>>8) != pixels[x*3+2] {
+			t.Errorf("Error: %v %v %v", x, img.At(x, 0), pixels[x*3:x*3+3])
+		}
+	}
+}
+
+func TestPPMRoundTrip(t *testing.T) {
+	c := rt.NewCanvas(7, 4)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			c.SetAt(x, y, &rt.Color{float64(x) / 6, float64(y) / 3, 0.2})
+		}
+	}
+
+	for _, format := range []string{"ppm", "ppm-plain"} {
+		var buf bytes.Buffer
+		if err := c.Encode(&buf, format); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		decoded, err := rt.DecodePPM(&buf)
+		if err != nil {
+			t.Fatalf("Error: %v %v", format, err)
+		}
+		if decoded.Width != c.Width || decoded.Height != c.Height {
+			t.Fatalf("Error: %v %v", decoded.Width, decoded.Height)
+		}
+
+		// The colors are rounded to 1/255, but writing them again gives the
+		// same file.
+		for y := 0; y < c.Height; y++ {
+			for x := 0; x < c.Width; x++ {
+				diff := decoded.GetAt(x, y).Sub(c.GetAt(x, y))
+				if math.Abs(diff.R) > 1.0/255 || math.Abs(diff.G) > 1.0/255 || math.Abs(diff.B) > 1.0/255 {
+					t.Errorf("Error: %v %v %v %v", format, x, y, diff)
+				}
+			}
+		}
+
+		var again bytes.Buffer
+		decoded.Encode(&again, format)
+		var original bytes.Buffer
+		c.Encode(&original, format)
+		if again.String() != original.String() {
+			t.Errorf("Error: %v", format)
+		}
+	}
+}
+
+func TestDecodePPM(t *testing.T) {
+	examples := []struct {
+		data  string
+		color *rt.Color
+	}{
+		{"P3\n# a comment\n2 1\n# another\n10\n10 5 0  0 0 0\n", &rt.Color{1, 0.5, 0}},
+		{"P3 2 1 255 255 0 51 0 0 0", &rt.Color{1, 0, 0.2}},
+		{"P6\n2 1\n255\n\xff\x00\x33\x00\x00\x00", &rt.Color{1, 0, 0.2}},
+		{"P6\n2 1\n65535\n\xff\xff\x00\x00\x80\x00" + strings.Repeat("\x00", 6), &rt.Color{1, 0, 32768.0 / 65535}},
+		{"P2\n2 1\n4\n2 0\n", &rt.Color{0.5, 0.5, 0.5}},
+		{"P5\n2 1\n255\n\x33\x00", &rt.Color{0.2, 0.2, 0.2}},
+	}
+
+	for _, example := range examples {
+		c, err := rt.DecodePPM(strings.NewReader(example.data))
+		if err != nil {
+			t.Fatalf("Error: %q %v", example.data, err)
+		}
+
+		if color := c.GetAt(0, 0); !color.Equals(example.color) {
+			t.Errorf("Error: %q %v", example.data, color)
+		}
+		if color := c.GetAt(1, 0); !color.Equals(&rt.Color{0, 0, 0}) {
+			t.Errorf("Error: %q %v", example.data, color)
+		}
+	}
+}
+
+func TestDecodePPMInvalid(t *testing.T) {
+	examples := []string{
+		"",
+		"P7\n1 1\n255\n",
+		"P3\n1 1\n",
+		"P3\n0 1\n255\n",
+		"P3\n1 1\n255\n1 2\n",
+		"P3\n1 1\n255\n1 2 256\n",
+		"P3\n1 1\n255\n1 x 2\n",
+		"P6\n1 1\n255\n\x00\x00",
+	}
+
+	for _, example := range examples {
+		if _, err := rt.DecodePPM(strings.NewReader(example)); err == nil {
+			t.Errorf("Error: %q", example)
+		}
+	}
+}
+
+func TestEncodePGM(t *testing.T) {
+	c := rt.NewCanvas(2, 1)
+	c.SetAt(0, 0, &rt.Color{1, 1, 1})
+	c.SetAt(1, 0, &rt.Color{0, 1, 0})
+
+	var buf bytes.Buffer
+	if err := c.Encode(&buf, "pgm"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if pgm := buf.String(); pgm != "P5\n2 1\n255\n\xff\xb6" {
+		t.Errorf("Error: %q", pgm)
+	}
+}
@@ -0,0 +1,215 @@
+package raytracer
+
+import (
+	"context"
+	"image"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultTileSize is the width and height of the square tiles an image is
+// split into for rendering.
+const DefaultTileSize = 16
+
+type RenderOptions struct {
+	// Threads is the number of workers rendering tiles in parallel,
+	// defaulting to the number of CPUs.
+	Threads int
+	// TileSize is the width and height of a tile, defaulting to
+	// DefaultTileSize.
+	TileSize int
+	// Samples is the number of rays traced per pixel. Defaults to 1, which
+	// traces a single ray through the center of the pixel unless a Sampler is
+	// set.
+	Samples int
+	// Sampler places the samples within a pixel, defaulting to GridSampler.
+	Sampler Sampler
+	// Filter weighs the samples, defaulting to BoxFilter.
+	Filter Filter
+	// Seed seeds the random numbers used by the sampler. Renders with the
+	// same seed produce the same image.
+	Seed int64
+	// MaxSamples turns on adaptive sampling when it is larger than Samples.
+	// Pixels start with Samples rays and get batches of Samples more until
+	// their estimated noise drops below Threshold or MaxSamples is reached.
+	MaxSamples int
+	// Threshold is the standard error of the mean luminance of a pixel at
+	// which adaptive sampling stops, defaulting to DefaultThreshold.
+	Threshold float64
+	// SampleCounts, if set, is filled with the number of rays traced for
+	// each pixel.
+	SampleCounts *SampleCounts
+	// Progress, if set, is called after each finished tile. Calls never
+	// overlap.
+	Progress func(p RenderProgress)
+}
+
+type RenderProgress struct {
+	TilesDone   int
+	TilesTotal  int
+	PixelsDone  int
+	PixelsTotal int
+	Elapsed     time.Duration
+	// Remaining estimates the time left from the pixel rate so far.
+	Remaining time.Duration
+}
+
+func (c *Camera) Render(w *World) *Canvas {
+	return c.RenderWithOptions(w, RenderOptions{})
+}
+
+func (c *Camera) RenderWithOptions(w *World, opts RenderOptions) *Canvas {
+	canvas, _ := c.RenderContext(context.Background(), w, opts)
+	return canvas
+}
+
+// Tiles splits the image of c into tiles of at most size by size pixels, in
+// rows from the top left.
+func (c *Camera) Tiles(size int) []image.Rectangle {
+	tiles := []image.Rectangle{}
+	for y := 0; y < c.VSize; y += size {
+		for x := 0; x < c.HSize; x += size {
+			tile := image.Rect(x, y, x+size, y+size)
+			tiles = append(tiles, tile.Intersect(image.Rect(0, 0, c.HSize, c.VSize)))
+		}
+	}
+
+	return tiles
+}
+
+// RenderContext renders the tiles of the image on a pool of workers. Every
+// pixel is traced independently and written by exactly one worker, so the
+// result is the same for any number of workers.
+//
+// When ctx is cancelled the workers stop after their current row and
+// RenderContext returns the partially rendered canvas with ctx.Err().
+func (c *Camera) RenderContext(ctx context.Context, w *World, opts RenderOptions) (*Canvas, error) {
+	threads := opts.Threads
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	tileSize := opts.TileSize
+	if tileSize <= 0 {
+		tileSize = DefaultTileSize
+	}
+	if opts.Samples <= 0 {
+		opts.Samples = 1
+	}
+	if opts.Filter == nil {
+		opts.Filter = BoxFilter{}
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultThreshold
+	}
+	if opts.SampleCounts != nil {
+		*opts.SampleCounts = *NewSampleCounts(c.HSize, c.VSize)
+	}
+
+	canvas := NewCanvas(c.HSize, c.VSize)
+
+	tiles := c.Tiles(tileSize)
+	queue := make(chan image.Rectangle, len(tiles))
+	for _, tile := range tiles {
+		queue <- tile
+	}
+	close(queue)
+
+	start := time.Now()
+	var mu sync.Mutex
+	progress := RenderProgress{TilesTotal: len(tiles), PixelsTotal: c.HSize * c.VSize}
+
+	var wg sync.WaitGroup
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tracer := NewTracer(w)
+			for tile := range queue {
+				for y := tile.Min.Y; y < tile.Max.Y; y++ {
+					if ctx.Err() != nil {
+						return
+					}
+					for x := tile.Min.X; x < tile.Max.X; x++ {
+						color, samples := c.colorAtPixel(tracer, x, y, &opts)
+						canvas.SetAt(x, y, color)
+						if opts.SampleCounts != nil {
+							opts.SampleCounts.Set(x, y, samples)
+						}
+					}
+				}
+
+				mu.Lock()
+				progress.TilesDone++
+				progress.PixelsDone += tile.Dx() * tile.Dy()
+				progress.Elapsed = time.Since(start)
+				remaining := progress.PixelsTotal - progress.PixelsDone
+				progress.Remaining = progress.Elapsed * time.Duration(remaining) / time.Duration(progress.PixelsDone)
+				if opts.Progress != nil {
+					opts.Progress(progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if progress.TilesDone < progress.TilesTotal {
+		return canvas, ctx.Err()
+	}
+
+	return canvas, nil
+}
+
+// colorAtPixel traces the samples of the pixel at (x, y), spread over the
+// radius of the filter around its center, and returns their weighted average
+// along with the number of rays traced.
+func (c *Camera) colorAtPixel(tracer *Tracer, x, y int, opts *RenderOptions) (*Color, int) {
+	if opts.Samples == 1 && opts.Sampler == nil && opts.MaxSamples <= 1 {
+		return tracer.ColorAt(c.RayForPixel(x, y)), 1
+	}
+
+	sampler := opts.Sampler
+	if sampler == nil {
+		sampler = GridSampler{}
+	}
+	radius := opts.Filter.Radius()
+
+	rng := pixelRNG(opts.Seed, x, y)
+	adaptive := opts.MaxSamples > opts.Samples
+	var points []SamplePoint
+	if adaptive {
+		points = progressiveSamples(sampler, opts.MaxSamples, rng)
+	} else {
+		points = sampler.Samples(opts.Samples, rng)
+	}
+
+	sum, total := &Color{}, 0.0
+	var stats luminanceStats
+	traced := 0
+	for traced < len(points) {
+		p := points[traced]
+		traced++
+
+		dx, dy := (2*p.X-1)*radius, (2*p.Y-1)*radius
+		if weight := opts.Filter.Weight(dx, dy); weight != 0 {
+			color := tracer.ColorAt(c.RayForPixelOffset(x, y, 0.5+dx, 0.5+dy))
+			sum = sum.Add(color.Mul(weight))
+			total += weight
+			stats.add(color)
+		}
+
+		if adaptive && traced%opts.Samples == 0 && stats.standardError() < opts.Threshold {
+			break
+		}
+	}
+
+	// Filters with negative lobes can leave next to no weight when only a
+	// few samples are taken, leaving the pixel black is better than blowing
+	// it up.
+	if total <= 0 {
+		return &Color{}, traced
+	}
+
+	return sum.Mul(1 / total), traced
+}
@@ -0,0 +1,240 @@
+package raytracer_test
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestRenderWithOptions(t *testing.T) {
+	w := rt.DefaultWorld()
+	c := rt.NewCamera(11, 11, math.Pi/2)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(0, 0, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	expected := c.Render(w)
+
+	updates := []rt.RenderProgress{}
+	image := c.RenderWithOptions(w, rt.RenderOptions{
+		Threads:  3,
+		TileSize: 4,
+		Progress: func(p rt.RenderProgress) {
+			updates = append(updates, p)
+		},
+	})
+
+	for y := 0; y < 11; y++ {
+		for x := 0; x < 11; x++ {
+			if !image.GetAt(x, y).Equals(expected.GetAt(x, y)) {
+				t.Errorf("Error: %v %v %v", x, y, image.GetAt(x, y))
+			}
+		}
+	}
+
+	if len(updates) != 9 {
+		t.Fatalf("Error: %v", updates)
+	}
+
+	pixels := 0
+	for idx, p := range updates {
+		if p.TilesDone != idx+1 || p.TilesTotal != 9 || p.PixelsTotal != 121 {
+			t.Errorf("Error: %v", p)
+		}
+		if p.PixelsDone <= pixels || p.Elapsed < 0 || p.Remaining < 0 {
+			t.Errorf("Error: %v", p)
+		}
+		pixels = p.PixelsDone
+	}
+
+	if last := updates[8]; last.PixelsDone != 121 || last.Remaining != 0 {
+		t.Errorf("Error: %v", last)
+	}
+}
+
+func TestRenderWithSamples(t *testing.T) {
+	/* Scenario: Several samples per pixel smooth the edge of a sphere
+	   Given w ← default_world()
+	     And c ← camera(11, 11, π/2) looking at the outer sphere
+	   When image ← render(c, w) with 16 samples per pixel
+	   Then the pixels on the edge of the sphere change */
+	w := rt.DefaultWorld()
+	c := rt.NewCamera(11, 11, math.Pi/2)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(0, 0, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	single := c.Render(w)
+	sampled := c.RenderWithOptions(w, rt.RenderOptions{Samples: 16})
+
+	// The centre pixel is entirely covered by the sphere, so the samples
+	// only differ slightly in shading.
+	center := sampled.GetAt(5, 5).Sub(single.GetAt(5, 5))
+	if math.Abs(center.R) > 0.05 || math.Abs(center.G) > 0.05 || math.Abs(center.B) > 0.05 {
+		t.Errorf("Error: %v", sampled.GetAt(5, 5))
+	}
+
+	edges := 0
+	for x := 0; x < 11; x++ {
+		if !single.GetAt(x, 5).Equals(sampled.GetAt(x, 5)) {
+			edges++
+		}
+	}
+
+	if edges == 0 {
+		t.Errorf("Error: %v", edges)
+	}
+}
+
+func TestCameraTiles(t *testing.T) {
+	/* Scenario: Splitting the image of a camera into tiles
+	   Given c ← camera(40, 20, π/2)
+	   When tiles ← tiles(c, 16)
+	   Then tiles.count = 6
+	     And the tiles on the right and bottom edge are clipped to the image */
+	c := rt.NewCamera(40, 20, math.Pi/2)
+
+	tiles := c.Tiles(16)
+
+	expected := []image.Rectangle{
+		image.Rect(0, 0, 16, 16),
+		image.Rect(16, 0, 32, 16),
+		image.Rect(32, 0, 40, 16),
+		image.Rect(0, 16, 16, 20),
+		image.Rect(16, 16, 32, 20),
+		image.Rect(32, 16, 40, 20),
+	}
+
+	if len(tiles) != len(expected) {
+		t.Fatalf("Error: %v", tiles)
+	}
+
+	for idx, tile := range tiles {
+		if tile != expected[idx] {
+			t.Errorf("Error: %v %v", idx, tile)
+		}
+	}
+}
+
+func TestRenderDeterministic(t *testing.T) {
+	/* Scenario: Rendering on more threads gives the same image
+	   Given w ← a world with a reflective sphere, a group and a CSG shape
+	     And c ← camera(37, 23, π/2) split into 5x5 tiles
+	   When image ← render(c, w) on 2, 3 or 8 threads
+	   Then every pixel of image equals the one rendered on a single thread */
+	w := rt.DefaultWorld()
+	w.Objects[0].GetMaterial().Reflective = 0.5
+	g := rt.NewGroup()
+	g.AddChild(rt.NewCube(), rt.NewCSG(rt.CSGDifference, rt.NewSphere(), rt.NewCube()))
+	g.SetTransform(rt.Translation(2, 0, 1))
+	w.AddObject(g)
+	c := rt.NewCamera(37, 23, math.Pi/2)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(1, 2, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	expected := c.RenderWithOptions(w, rt.RenderOptions{Threads: 1, TileSize: 5, Samples: 4})
+
+	for _, threads := range []int{2, 3, 8} {
+		image := c.RenderWithOptions(w, rt.RenderOptions{Threads: threads, TileSize: 5, Samples: 4})
+
+		for y := 0; y < c.VSize; y++ {
+			for x := 0; x < c.HSize; x++ {
+				if *image.GetAt(x, y) != *expected.GetAt(x, y) {
+					t.Fatalf("Error: %v %v %v", threads, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderContextCancelled(t *testing.T) {
+	w := rt.DefaultWorld()
+	c := rt.NewCamera(64, 64, math.Pi/2)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(0, 0, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tiles := 0
+	canvas, err := c.RenderContext(ctx, w, rt.RenderOptions{
+		Threads:  1,
+		TileSize: 8,
+		Progress: func(p rt.RenderProgress) {
+			tiles = p.TilesDone
+			if p.TilesDone == 3 {
+				cancel()
+			}
+		},
+	})
+
+	if err != context.Canceled {
+		t.Errorf("Error: %v", err)
+	}
+
+	if canvas == nil || tiles != 3 {
+		t.Errorf("Error: %v %v", canvas, tiles)
+	}
+
+	// Only the first three tiles of the top row were rendered, the sphere in
+	// the middle of the image was not.
+	if color := canvas.GetAt(32, 32); !color.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", color)
+	}
+	if color := c.Render(w).GetAt(32, 32); color.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestRenderContextTimeout(t *testing.T) {
+	w := rt.DefaultWorld()
+	c := rt.NewCamera(2000, 2000, math.Pi/2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.RenderContext(ctx, w, rt.RenderOptions{})
+
+	if err != context.DeadlineExceeded {
+		t.Errorf("Error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Error: %v", elapsed)
+	}
+}
+
+func TestRenderContextCompleted(t *testing.T) {
+	w := rt.DefaultWorld()
+	c := rt.NewCamera(8, 8, math.Pi/2)
+
+	canvas, err := c.RenderContext(context.Background(), w, rt.RenderOptions{})
+
+	if err != nil || canvas == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func BenchmarkRenderThreads(b *testing.B) {
+	w := rt.DefaultWorld()
+	w.Objects[0].GetMaterial().Reflective = 0.5
+	floor := rt.NewPlane()
+	floor.SetTransform(rt.Translation(0, -1, 0))
+	w.AddObject(floor)
+
+	c := rt.NewCamera(160, 120, math.Pi/3)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(0, 1.5, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	threads := []int{1, 2, 4, 8}
+	if cpus := runtime.NumCPU(); cpus > 8 {
+		threads = append(threads, cpus)
+	}
+
+	for _, n := range threads {
+		b.Run(fmt.Sprintf("threads=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				c.RenderWithOptions(w, rt.RenderOptions{Threads: n})
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package raytracer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// toRGBE packs a color into Radiance's shared exponent format: three 8 bit
+// mantissas and one exponent byte.
+func toRGBE(c *Color) [4]byte {
+	v := math.Max(c.R, math.Max(c.G, c.B))
+	if v < 1e-32 {
+		return [4]byte{}
+	}
+
+	mantissa, exponent := math.Frexp(v)
+	scale := mantissa * 256 / v
+
+	return [4]byte{
+		byte(math.Max(0, c.R) * scale),
+		byte(math.Max(0, c.G) * scale),
+		byte(math.Max(0, c.B) * scale),
+		byte(exponent + 128),
+	}
+}
+
+func fromRGBE(rgbe []byte) *Color {
+	if rgbe[3] == 0 {
+		return &Color{}
+	}
+
+	f := math.Ldexp(1, int(rgbe[3])-(128+8))
+	return &Color{
+		(float64(rgbe[0]) + 0.5) * f,
+		(float64(rgbe[1]) + 0.5) * f,
+		(float64(rgbe[2]) + 0.5) * f,
+	}
+}
+
+// EncodeHDR writes c as a Radiance RGBE image with run length encoded
+// scanlines.
+func EncodeHDR(w io.Writer, c *Canvas) error {
+	header := fmt.Sprintf("#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y %d +X %d\n", c.Height, c.Width)
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	scanline := make([]byte, c.Width*4)
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			rgbe := toRGBE(c.GetAt(x, y))
+			copy(scanline[x*4:], rgbe[:])
+		}
+
+		var err error
+		if c.Width < 8 || c.Width > 0x7fff {
+			// Run length encoding only exists for these widths.
+			_, err = w.Write(scanline)
+		} else {
+			err = writeRLEScanline(w, scanline, c.Width)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeRLEScanline writes a scanline in the "new" Radiance run length
+// encoding, which stores each of the four components separately as a mix of
+// runs of one repeated byte and literal stretches.
+func writeRLEScanline(w io.Writer, scanline []byte, width int) error {
+	out := []byte{2, 2, byte(width >> 8), byte(width)}
+
+	component := make([]byte, width)
+	for channel := 0; channel < 4; channel++ {
+		for x := 0; x < width; x++ {
+			component[x] = scanline[x*4+channel]
+		}
+
+		for x := 0; x < width; {
+			run := 1
+			for x+run < width && run < 127 && component[x+run] == component[x] {
+				run++
+			}
+			if run > 2 {
+				out = append(out, byte(128+run), component[x])
+				x += run
+				continue
+			}
+
+			// Collect literals up to the next run of at least three.
+			end := x
+			for end < width && end-x < 128 {
+				if end+2 < width && component[end] == component[end+1] && component[end] == component[end+2] {
+					break
+				}
+				end++
+			}
+			out = append(out, byte(end-x))
+			out = append(out, component[x:end]...)
+			x = end
+		}
+	}
+
+	_, err := w.Write(out)
+	return err
+}
+
+// DecodeHDR reads a Radiance RGBE image, flat or run length encoded, in the
+// usual -Y height +X width orientation.
+func DecodeHDR(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	line, err := br.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "#?") {
+		return nil, fmt.Errorf("hdr: missing #? signature")
+	}
+
+	for {
+		line, err = br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("hdr: invalid header: %v", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT=32-bit_rle_rgbe" {
+			return nil, fmt.Errorf("hdr: unsupported %s", line)
+		}
+	}
+
+	line, err = br.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("hdr: missing resolution: %v", err)
+	}
+	var width, height int
+	if _, err := fmt.Sscanf(line, "-Y %d +X %d", &height, &width); err != nil {
+		return nil, fmt.Errorf("hdr: unsupported resolution %q", strings.TrimSpace(line))
+	}
+	if !validDecodedSize(int64(width), int64(height), 3) {
+		return nil, fmt.Errorf("hdr: invalid size %dx%d", width, height)
+	}
+
+	c := NewCanvas(width, height)
+	scanline := make([]byte, width*4)
+	for y := 0; y < height; y++ {
+		if err := readHDRScanline(br, scanline, width); err != nil {
+			return nil, fmt.Errorf("hdr: scanline %d: %v", y, err)
+		}
+		for x := 0; x < width; x++ {
+			c.SetAt(x, y, fromRGBE(scanline[x*4:x*4+4]))
+		}
+	}
+
+	return c, nil
+}
+
+func readHDRScanline(br *bufio.Reader, scanline []byte, width int) error {
+	start, err := br.Peek(4)
+	if err != nil {
+		return err
+	}
+
+	rle := width >= 8 && width <= 0x7fff && start[0] == 2 && start[1] == 2 && start[2]&0x80 == 0
+	if !rle {
+		_, err := io.ReadFull(br, scanline)
+		return err
+	}
+
+	if int(start[2])<<8|int(start[3]) != width {
+		return fmt.Errorf("scanline width doesn't match the image")
+	}
+	br.Discard(4)
+
+	for channel := 0; channel < 4; channel++ {
+		for x := 0; x < width; {
+			count, err := br.ReadByte()
+			if err != nil {
+				return err
+			}
+
+			if count > 128 {
+				run := int(count) - 128
+				value, err := br.ReadByte()
+				if err != nil {
+					return err
+				}
+				if x+run > width {
+					return fmt.Errorf("run overflows the scanline")
+				}
+				for i := 0; i < run; i++ {
+					scanline[(x+i)*4+channel] = value
+				}
+				x += run
+			} else {
+				if count == 0 || x+int(count) > width {
+					return fmt.Errorf("invalid literal count %d", count)
+				}
+				for i := 0; i < int(count); i++ {
+					value, err := br.ReadByte()
+					if err != nil {
+						return err
+					}
+					scanline[(x+i)*4+channel] = value
+				}
+				x += int(count)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package raytracer
+
+import (
+	"fmt"
+	"math"
+)
+
+// RNG is a small, fast pseudo random number generator (SplitMix64). Renders
+// seed one per pixel so the result does not depend on which worker traces
+// which pixel.
+type RNG struct {
+	state uint64
+}
+
+func NewRNG(seed uint64) *RNG {
+	return &RNG{seed}
+}
+
+func (r *RNG) Uint64() uint64 {
+	r.state += 0x9e3779b97f4a7c15
+	z := r.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Float64 returns a number in [0, 1).
+func (r *RNG) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// pixelRNG returns the generator for the pixel at (x, y) of a render seeded
+// with seed.
+func pixelRNG(seed int64, x, y int) *RNG {
+	base := NewRNG(uint64(seed)).Uint64()
+	return NewRNG(base ^ (uint64(uint32(y))<<32 | uint64(uint32(x))))
+}
+
+// SamplePoint is a position within a pixel, with both coordinates in [0, 1).
+type SamplePoint struct {
+	X, Y float64
+}
+
+// Sampler decides where in a pixel its n rays are traced.
+type Sampler interface {
+	Samples(n int, rng *RNG) []SamplePoint
+}
+
+// gridSize spreads n samples over a grid that is as square as possible.
+func gridSize(n int) (int, int) {
+	cols := int(math.Ceil(math.Sqrt(float64(n))))
+	rows := (n + cols - 1) / cols
+	return cols, rows
+}
+
+// GridSampler places the samples at the centers of the cells of a regular
+// grid.
+type GridSampler struct{}
+
+func (GridSampler) Samples(n int, rng *RNG) []SamplePoint {
+	cols, rows := gridSize(n)
+	points := make([]SamplePoint, n)
+	for i := range points {
+		points[i] = SamplePoint{
+			(float64(i%cols) + 0.5) / float64(cols),
+			(float64(i/cols) + 0.5) / float64(rows),
+		}
+	}
+
+	return points
+}
+
+// JitteredSampler places one sample at a random position in each cell of a
+// regular grid.
+type JitteredSampler struct{}
+
+func (JitteredSampler) Samples(n int, rng *RNG) []SamplePoint {
+	cols, rows := gridSize(n)
+	points := make([]SamplePoint, n)
+	for i := range points {
+		points[i] = SamplePoint{
+			(float64(i%cols) + rng.Float64()) / float64(cols),
+			(float64(i/cols) + rng.Float64()) / float64(rows),
+		}
+	}
+
+	return points
+}
+
+// RandomSampler places the samples anywhere in the pixel.
+type RandomSampler struct{}
+
+func (RandomSampler) Samples(n int, rng *RNG) []SamplePoint {
+	points := make([]SamplePoint, n)
+	for i := range points {
+		points[i] = SamplePoint{rng.Float64(), rng.Float64()}
+	}
+
+	return points
+}
+
+// HaltonSampler uses the Halton sequence in bases 2 and 3, shifted by a
+// random offset per pixel so neighbouring pixels don't share a pattern.
+type HaltonSampler struct{}
+
+func (HaltonSampler) Samples(n int, rng *RNG) []SamplePoint {
+	dx, dy := rng.Float64(), rng.Float64()
+	points := make([]SamplePoint, n)
+	for i := range points {
+		points[i] = SamplePoint{
+			mod1(radicalInverse(i+1, 2) + dx),
+			mod1(radicalInverse(i+1, 3) + dy),
+		}
+	}
+
+	return points
+}
+
+func radicalInverse(i, base int) float64 {
+	inverse, fraction := 0.0, 1.0/float64(base)
+	for scale := fraction; i > 0; i /= base {
+		inverse += float64(i%base) * scale
+		scale *= fraction
+	}
+
+	return inverse
+}
+
+// SobolSampler uses the first two dimensions of the Sobol sequence,
+// scrambled with a random bit pattern per pixel.
+type SobolSampler struct{}
+
+func (SobolSampler) Samples(n int, rng *RNG) []SamplePoint {
+	scrambleX, scrambleY := uint32(rng.Uint64()), uint32(rng.Uint64())
+	points := make([]SamplePoint, n)
+	for i := range points {
+		x, y := sobol(uint32(i))
+		points[i] = SamplePoint{
+			float64(x^scrambleX) / (1 << 32),
+			float64(y^scrambleY) / (1 << 32),
+		}
+	}
+
+	return points
+}
+
+// sobol returns the i-th point of the two dimensional Sobol sequence as
+// 32 bit fractions. The first dimension is the van der Corput sequence, the
+// second uses the direction numbers of the polynomial x + 1.
+func sobol(i uint32) (uint32, uint32) {
+	var x, y uint32
+	v := uint32(1 << 31)
+	for bit := uint32(0); i != 0; i, bit = i>>1, bit+1 {
+		if i&1 != 0 {
+			x ^= 1 << (31 - bit)
+			y ^= v
+		}
+		v ^= v >> 1
+	}
+
+	return x, y
+}
+
+// Filter weighs the samples of a pixel by their distance from its center,
+// in pixels. Samples are spread over the filter's radius around the center.
+type Filter interface {
+	Radius() float64
+	Weight(dx, dy float64) float64
+}
+
+// BoxFilter weighs all samples in the pixel equally.
+type BoxFilter struct{}
+
+func (BoxFilter) Radius() float64 {
+	return 0.5
+}
+
+func (BoxFilter) Weight(dx, dy float64) float64 {
+	return 1
+}
+
+// TentFilter weighs samples linearly less the further they are from the
+// center, reaching into the neighbouring pixels.
+type TentFilter struct{}
+
+func (TentFilter) Radius() float64 {
+	return 1
+}
+
+func (TentFilter) Weight(dx, dy float64) float64 {
+	return math.Max(0, 1-math.Abs(dx)) * math.Max(0, 1-math.Abs(dy))
+}
+
+// GaussianFilter weighs samples by a Gaussian with the given standard
+// deviation, cut off at three deviations.
+type GaussianFilter struct {
+	Sigma float64
+}
+
+func NewGaussianFilter() *GaussianFilter {
+	return &GaussianFilter{0.5}
+}
+
+func (f *GaussianFilter) Radius() float64 {
+	return 3 * f.Sigma
+}
+
+func (f *GaussianFilter) Weight(dx, dy float64) float64 {
+	return math.Exp(-(dx*dx + dy*dy) / (2 * f.Sigma * f.Sigma))
+}
+
+// MitchellFilter is the Mitchell-Netravali cubic filter, whose small negative
+// lobes keep edges sharper than the tent or Gaussian filters.
+type MitchellFilter struct {
+	B, C float64
+}
+
+func NewMitchellFilter() *MitchellFilter {
+	return &MitchellFilter{1.0 / 3, 1.0 / 3}
+}
+
+func (f *MitchellFilter) Radius() float64 {
+	return 2
+}
+
+func (f *MitchellFilter) Weight(dx, dy float64) float64 {
+	return f.weight1D(dx) * f.weight1D(dy)
+}
+
+func (f *MitchellFilter) weight1D(x float64) float64 {
+	b, c := f.B, f.C
+	x = math.Abs(x)
+
+	switch {
+	case x < 1:
+		return ((12-9*b-6*c)*x*x*x + (-18+12*b+6*c)*x*x + (6 - 2*b)) / 6
+	case x < 2:
+		return ((-b-6*c)*x*x*x + (6*b+30*c)*x*x + (-12*b-48*c)*x + (8*b + 24*c)) / 6
+	}
+
+	return 0
+}
+
+func SamplerByName(name string) (Sampler, error) {
+	switch name {
+	case "grid":
+		return GridSampler{}, nil
+	case "jittered":
+		return JitteredSampler{}, nil
+	case "random":
+		return RandomSampler{}, nil
+	case "halton":
+		return HaltonSampler{}, nil
+	case "sobol":
+		return SobolSampler{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown sampler %q", name)
+}
+
+func FilterByName(name string) (Filter, error) {
+	switch name {
+	case "box":
+		return BoxFilter{}, nil
+	case "tent":
+		return TentFilter{}, nil
+	case "gaussian":
+		return NewGaussianFilter(), nil
+	case "mitchell":
+		return NewMitchellFilter(), nil
+	}
+
+	return nil, fmt.Errorf("unknown filter %q", name)
+}
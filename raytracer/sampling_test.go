@@ -0,0 +1,216 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestRNG(t *testing.T) {
+	a, b, c := rt.NewRNG(42), rt.NewRNG(42), rt.NewRNG(43)
+
+	different := false
+	for i := 0; i < 100; i++ {
+		x, y, z := a.Float64(), b.Float64(), c.Float64()
+		if x != y {
+			t.Fatalf("Error: %v %v", x, y)
+		}
+		if x < 0 || x >= 1 {
+			t.Errorf("Error: %v", x)
+		}
+		if x != z {
+			different = true
+		}
+	}
+
+	if !different {
+		t.Errorf("Error: %v", different)
+	}
+}
+
+func TestSamplers(t *testing.T) {
+	samplers := []string{"grid", "jittered", "random", "halton", "sobol"}
+
+	for _, name := range samplers {
+		sampler, err := rt.SamplerByName(name)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		for _, n := range []int{1, 2, 5, 16} {
+			points := sampler.Samples(n, rt.NewRNG(7))
+			again := sampler.Samples(n, rt.NewRNG(7))
+
+			if len(points) != n {
+				t.Errorf("Error: %v %v", name, points)
+			}
+
+			for idx, p := range points {
+				if p.X < 0 || p.X >= 1 || p.Y < 0 || p.Y >= 1 {
+					t.Errorf("Error: %v %v", name, p)
+				}
+				if p != again[idx] {
+					t.Errorf("Error: %v %v %v", name, p, again[idx])
+				}
+			}
+		}
+	}
+
+	if _, err := rt.SamplerByName("poisson"); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestGridSampler(t *testing.T) {
+	points := rt.GridSampler{}.Samples(4, rt.NewRNG(0))
+
+	expected := []rt.SamplePoint{{0.25, 0.25}, {0.75, 0.25}, {0.25, 0.75}, {0.75, 0.75}}
+	for idx, p := range points {
+		if p != expected[idx] {
+			t.Errorf("Error: %v", p)
+		}
+	}
+}
+
+// strata counts how many points fall into each cell of a cols by rows grid.
+func strata(points []rt.SamplePoint, cols, rows int) map[[2]int]int {
+	cells := map[[2]int]int{}
+	for _, p := range points {
+		cells[[2]int{int(p.X * float64(cols)), int(p.Y * float64(rows))}]++
+	}
+	return cells
+}
+
+func TestStratifiedSamplers(t *testing.T) {
+	/* The jittered sampler has one sample per cell of a 4x4 grid, and the
+	   Sobol sampler, being a (0, 2)-sequence, has one sample in every
+	   elementary interval of 16 samples: 4x4, 16x1, 8x2, 2x8 and 1x16. */
+	jittered := rt.JitteredSampler{}.Samples(16, rt.NewRNG(3))
+	if cells := strata(jittered, 4, 4); len(cells) != 16 {
+		t.Errorf("Error: %v", cells)
+	}
+
+	sobol := rt.SobolSampler{}.Samples(16, rt.NewRNG(3))
+	for _, grid := range [][2]int{{4, 4}, {16, 1}, {8, 2}, {2, 8}, {1, 16}} {
+		if cells := strata(sobol, grid[0], grid[1]); len(cells) != 16 {
+			t.Errorf("Error: %v %v", grid, cells)
+		}
+	}
+}
+
+func TestHaltonSampler(t *testing.T) {
+	/* Consecutive Halton points differ by the same shift in every pixel, so
+	   the differences follow the radical inverses in bases 2 and 3. */
+	points := rt.HaltonSampler{}.Samples(3, rt.NewRNG(11))
+
+	wrap := func(x float64) float64 { return x - math.Floor(x) }
+
+	if math.Abs(wrap(points[1].X-points[0].X)-0.75) > 0.00001 {
+		t.Errorf("Error: %v", points)
+	}
+
+	if math.Abs(wrap(points[1].Y-points[0].Y)-1.0/3) > 0.00001 {
+		t.Errorf("Error: %v", points)
+	}
+
+	if math.Abs(wrap(points[2].X-points[0].X)-0.25) > 0.00001 {
+		t.Errorf("Error: %v", points)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	examples := []struct {
+		name     string
+		dx, dy   float64
+		expected float64
+	}{
+		{"box", 0, 0, 1},
+		{"box", 0.4, -0.4, 1},
+		{"tent", 0, 0, 1},
+		{"tent", 0.5, 0, 0.5},
+		{"tent", 0.5, 0.5, 0.25},
+		{"tent", 1, 0, 0},
+		{"gaussian", 0, 0, 1},
+		{"gaussian", 0.5, 0, math.Exp(-0.5)},
+		{"mitchell", 0, 0, 64.0 / 81},
+		{"mitchell", 1, 0, 8.0 / 9 / 18},
+		{"mitchell", 2, 0, 0},
+	}
+
+	for _, example := range examples {
+		filter, err := rt.FilterByName(example.name)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		if w := filter.Weight(example.dx, example.dy); math.Abs(w-example.expected) > 0.00001 {
+			t.Errorf("Error: %v %v", example, w)
+		}
+	}
+
+	if _, err := rt.FilterByName("lanczos"); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestRenderSupersampled(t *testing.T) {
+	/* Scenario: Supersampling smooths the silhouette of a sphere
+	   Given a white sphere on a black background
+	   When it is rendered with 16 jittered samples per pixel
+	   Then some pixels on its edge are grey
+	     And rendering again with the same seed gives the same image
+	     And rendering with another seed gives a different image */
+	w := rt.NewWorld()
+	sphere := rt.NewSphere()
+	sphere.Material.Ambient = 1
+	sphere.Material.Diffuse = 0
+	sphere.Material.Specular = 0
+	w.AddObject(sphere)
+	w.AddLight(rt.NewPointLight(rt.NewPoint(0, 0, -10), &rt.Color{1, 1, 1}))
+
+	c := rt.NewCamera(21, 21, math.Pi/3)
+	c.SetTransform(rt.ViewTransform(rt.NewPoint(0, 0, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0)))
+
+	render := func(seed int64, threads int) *rt.Canvas {
+		return c.RenderWithOptions(w, rt.RenderOptions{
+			Threads: threads,
+			Samples: 16,
+			Sampler: rt.JitteredSampler{},
+			Filter:  rt.TentFilter{},
+			Seed:    seed,
+		})
+	}
+
+	image := render(1, 1)
+
+	grey := 0
+	for y := 0; y < 21; y++ {
+		for x := 0; x < 21; x++ {
+			if c := image.GetAt(x, y); c.R > 0.05 && c.R < 0.95 {
+				grey++
+			}
+		}
+	}
+
+	if grey == 0 {
+		t.Errorf("Error: %v", grey)
+	}
+
+	same, other := render(1, 4), render(2, 1)
+	changed := false
+	for y := 0; y < 21; y++ {
+		for x := 0; x < 21; x++ {
+			if *same.GetAt(x, y) != *image.GetAt(x, y) {
+				t.Fatalf("Error: %v %v", x, y)
+			}
+			if *other.GetAt(x, y) != *image.GetAt(x, y) {
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		t.Errorf("Error: %v", changed)
+	}
+}
@@ -0,0 +1,918 @@
+package raytracer
+
+import (
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Scene is a world together with the camera looking at it, as described by a
+// JSON scene file.
+//
+// A scene file has a camera, a list of lights and a list of shapes. Values
+// can be given a name under "define" and then be used by name wherever an
+// object or array is expected, and objects can build on a definition with
+// "extends". Transforms are lists of operations like ["translate", 0, 1, 0]
+// that are applied in order; a name in a transform list inserts the
+// operations of that definition.
+type Scene struct {
+	Camera *Camera
+	World  *World
+}
+
+type sceneLoader struct {
+	dir     string
+	defines map[string]*sceneNode
+	// resolving holds the names whose definitions are being read, innermost
+	// last. See scope.
+	resolving []string
+}
+
+func LoadScene(filename string) (*Scene, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseScene(data, filepath.Dir(filename))
+}
+
+// ParseScene reads a scene from r. Files referenced by the scene, like OBJ
+// models and image textures, are resolved relative to the working directory.
+func ParseScene(r io.Reader) (*Scene, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseScene(data, "")
+}
+
+func parseScene(data []byte, dir string) (*Scene, error) {
+	root, err := parseSceneNodes(data)
+	if err != nil {
+		return nil, err
+	}
+	if !root.isObject() {
+		return nil, root.errorf("expected an object")
+	}
+	if err := root.checkKeys("camera", "lights", "shapes", "define", "max_depth"); err != nil {
+		return nil, err
+	}
+
+	loader := &sceneLoader{dir: dir, defines: map[string]*sceneNode{}}
+	if defines := root.get("define"); defines != nil {
+		if !defines.isObject() {
+			return nil, defines.errorf("expected an object")
+		}
+		loader.defines = defines.object
+	}
+
+	scene := &Scene{World: NewWorld()}
+
+	cameraNode := root.get("camera")
+	if cameraNode == nil {
+		return nil, root.errorf("missing camera")
+	}
+	if scene.Camera, err = loader.camera(cameraNode); err != nil {
+		return nil, err
+	}
+
+	if depth := root.get("max_depth"); depth != nil {
+		if scene.World.MaxDepth, err = depth.int(); err != nil {
+			return nil, err
+		}
+	}
+
+	if lights := root.get("lights"); lights != nil {
+		release := loader.scope()
+		if lights, err = loader.resolve(lights); err != nil {
+			return nil, err
+		}
+		if !lights.isArray() {
+			return nil, lights.errorf("expected an array")
+		}
+		for _, node := range lights.array {
+			light, err := loader.light(node)
+			if err != nil {
+				return nil, err
+			}
+			scene.World.AddLight(light)
+		}
+		release()
+	}
+
+	if shapes := root.get("shapes"); shapes != nil {
+		release := loader.scope()
+		if shapes, err = loader.resolve(shapes); err != nil {
+			return nil, err
+		}
+		if !shapes.isArray() {
+			return nil, shapes.errorf("expected an array")
+		}
+		for _, node := range shapes.array {
+			shape, err := loader.shape(node, nil)
+			if err != nil {
+				return nil, err
+			}
+			scene.World.AddObject(shape)
+		}
+		release()
+	}
+
+	return scene, nil
+}
+
+// scope returns a function that releases the names resolved from now on.
+// Functions that resolve a value defer it, so a name stays in l.resolving
+// until everything nested in its definition has been read, and a definition
+// that uses itself anywhere inside is reported instead of recursing forever.
+func (l *sceneLoader) scope() func() {
+	depth := len(l.resolving)
+	return func() { l.resolving = l.resolving[:depth] }
+}
+
+// resolve replaces a name by its definition and merges objects with the
+// definition they extend. Values that are neither are returned as they are.
+// The names looked up stay in use until the scope of the caller ends.
+func (l *sceneLoader) resolve(n *sceneNode) (*sceneNode, error) {
+	if name, ok := n.value.(string); ok {
+		definition, ok := l.defines[name]
+		if !ok {
+			return nil, n.errorf("undefined name %q", name)
+		}
+		for _, resolving := range l.resolving {
+			if resolving == name {
+				return nil, n.errorf("definition %q refers to itself", name)
+			}
+		}
+		l.resolving = append(l.resolving, name)
+
+		return l.resolve(definition)
+	}
+
+	extends := n.get("extends")
+	if extends == nil {
+		return n, nil
+	}
+
+	base, err := l.resolve(extends)
+	if err != nil {
+		return nil, err
+	}
+	if !base.isObject() {
+		return nil, extends.errorf("can only extend an object")
+	}
+
+	merged := &sceneNode{Path: n.Path, Line: n.Line, object: map[string]*sceneNode{}}
+	for _, key := range base.keys {
+		if _, ok := n.object[key]; !ok {
+			merged.keys = append(merged.keys, key)
+			merged.object[key] = base.object[key]
+		}
+	}
+	for _, key := range n.keys {
+		if key != "extends" {
+			merged.keys = append(merged.keys, key)
+			merged.object[key] = n.object[key]
+		}
+	}
+
+	return merged, nil
+}
+
+func (l *sceneLoader) object(n *sceneNode) (*sceneNode, error) {
+	n, err := l.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	if !n.isObject() {
+		return nil, n.errorf("expected an object")
+	}
+
+	return n, nil
+}
+
+func (l *sceneLoader) path(n *sceneNode) (string, error) {
+	filename, err := n.str()
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(l.dir, filename)
+	}
+
+	return filename, nil
+}
+
+func (l *sceneLoader) tuple(n *sceneNode) ([]float64, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.floats(3)
+}
+
+func (l *sceneLoader) point(n *sceneNode) (Tuple, error) {
+	v, err := l.tuple(n)
+	if err != nil {
+		return Tuple{}, err
+	}
+
+	return NewPoint(v[0], v[1], v[2]), nil
+}
+
+func (l *sceneLoader) vector(n *sceneNode) (Tuple, error) {
+	v, err := l.tuple(n)
+	if err != nil {
+		return Tuple{}, err
+	}
+
+	return NewVector(v[0], v[1], v[2]), nil
+}
+
+func (l *sceneLoader) color(n *sceneNode) (*Color, error) {
+	v, err := l.tuple(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Color{v[0], v[1], v[2]}, nil
+}
+
+// required looks up key in n and reports it as missing when it isn't there.
+func required(n *sceneNode, key string) (*sceneNode, error) {
+	value := n.get(key)
+	if value == nil {
+		return nil, n.errorf("missing %s", key)
+	}
+
+	return value, nil
+}
+
+func (l *sceneLoader) camera(n *sceneNode) (*Camera, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.object(n)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.checkKeys("width", "height", "field_of_view", "from", "to", "up"); err != nil {
+		return nil, err
+	}
+
+	values := map[string]*sceneNode{}
+	for _, key := range []string{"width", "height", "field_of_view", "from", "to"} {
+		if values[key], err = required(n, key); err != nil {
+			return nil, err
+		}
+	}
+
+	width, err := values["width"].int()
+	if err != nil {
+		return nil, err
+	}
+	height, err := values["height"].int()
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 || height <= 0 {
+		return nil, n.errorf("width and height must be positive")
+	}
+	fov, err := values["field_of_view"].float()
+	if err != nil {
+		return nil, err
+	}
+	if fov <= 0 || fov >= math.Pi {
+		return nil, values["field_of_view"].errorf("field of view must be between 0 and pi radians")
+	}
+	from, err := l.point(values["from"])
+	if err != nil {
+		return nil, err
+	}
+	to, err := l.point(values["to"])
+	if err != nil {
+		return nil, err
+	}
+	up := NewVector(0, 1, 0)
+	if node := n.get("up"); node != nil {
+		if up, err = l.vector(node); err != nil {
+			return nil, err
+		}
+	}
+
+	if to.Sub(from).Mag() == 0 {
+		return nil, values["to"].errorf("camera looks from and to the same point")
+	}
+	if to.Sub(from).Cross(up).Mag() == 0 {
+		return nil, n.errorf("up must not be zero or parallel to the view direction")
+	}
+
+	camera := NewCamera(width, height, fov)
+	camera.SetTransform(ViewTransform(from, to, up))
+
+	return camera, nil
+}
+
+func (l *sceneLoader) light(n *sceneNode) (*PointLight, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.object(n)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.checkKeys("position", "intensity"); err != nil {
+		return nil, err
+	}
+
+	positionNode, err := required(n, "position")
+	if err != nil {
+		return nil, err
+	}
+	position, err := l.point(positionNode)
+	if err != nil {
+		return nil, err
+	}
+
+	intensity := &Color{1, 1, 1}
+	if node := n.get("intensity"); node != nil {
+		if intensity, err = l.color(node); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewPointLight(position, intensity), nil
+}
+
+// transform composes a list of transformations, applying them in the order
+// they are listed.
+func (l *sceneLoader) transform(n *sceneNode) (Matrix, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.resolve(n)
+	if err != nil {
+		return Matrix{}, err
+	}
+	if !n.isArray() {
+		return Matrix{}, n.errorf("expected a list of transformations")
+	}
+
+	transform := Identity()
+	for _, element := range n.array {
+		var step Matrix
+		if _, ok := element.value.(string); ok {
+			step, err = l.transform(element)
+		} else {
+			step, err = transformStep(element)
+		}
+		if err != nil {
+			return Matrix{}, err
+		}
+		transform = step.Mul(transform)
+	}
+	if math.Abs(transform.Det()) < epsilon {
+		return Matrix{}, n.errorf("transform is not invertible")
+	}
+
+	return transform, nil
+}
+
+func transformStep(n *sceneNode) (Matrix, error) {
+	if len(n.array) == 0 {
+		return Matrix{}, n.errorf("expected a transformation like [\"translate\", x, y, z]")
+	}
+
+	operation, err := n.array[0].str()
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	args := &sceneNode{Path: n.Path, Line: n.Line, array: n.array[1:]}
+	switch operation {
+	case "translate", "scale":
+		v, err := args.floats(3)
+		if err != nil {
+			return Matrix{}, err
+		}
+		if operation == "translate" {
+			return Translation(v[0], v[1], v[2]), nil
+		}
+		return Scaling(v[0], v[1], v[2]), nil
+	case "rotate-x", "rotate-y", "rotate-z":
+		v, err := args.floats(1)
+		if err != nil {
+			return Matrix{}, err
+		}
+		switch operation {
+		case "rotate-x":
+			return RotationX(v[0]), nil
+		case "rotate-y":
+			return RotationY(v[0]), nil
+		}
+		return RotationZ(v[0]), nil
+	case "shear":
+		v, err := args.floats(6)
+		if err != nil {
+			return Matrix{}, err
+		}
+		return Shearing(v[0], v[1], v[2], v[3], v[4], v[5]), nil
+	}
+
+	return Matrix{}, n.array[0].errorf("unknown transformation %q", operation)
+}
+
+func (l *sceneLoader) material(n *sceneNode) (*Material, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.object(n)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.checkKeys("color", "pattern", "ambient", "diffuse", "specular", "shininess",
+		"reflective", "transparency", "refractive_index"); err != nil {
+		return nil, err
+	}
+
+	material := NewMaterial()
+	if node := n.get("color"); node != nil {
+		if material.Color, err = l.color(node); err != nil {
+			return nil, err
+		}
+	}
+	if node := n.get("pattern"); node != nil {
+		if material.Pattern, err = l.pattern(node); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := []struct {
+		key   string
+		value *float64
+	}{
+		{"ambient", &material.Ambient},
+		{"diffuse", &material.Diffuse},
+		{"specular", &material.Specular},
+		{"shininess", &material.Shininess},
+		{"reflective", &material.Reflective},
+		{"transparency", &material.Transparency},
+		{"refractive_index", &material.RefractiveIndex},
+	}
+	for _, field := range fields {
+		if node := n.get(field.key); node != nil {
+			if *field.value, err = node.float(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return material, nil
+}
+
+// pattern reads a pattern object, or a color which becomes a solid pattern.
+func (l *sceneLoader) pattern(n *sceneNode) (Pattern, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	if n.isArray() {
+		c, err := l.color(n)
+		if err != nil {
+			return nil, err
+		}
+		return NewSolidPattern(c), nil
+	}
+	if !n.isObject() {
+		return nil, n.errorf("expected a pattern or a color")
+	}
+
+	typeNode, err := required(n, "type")
+	if err != nil {
+		return nil, err
+	}
+	patternType, err := typeNode.str()
+	if err != nil {
+		return nil, err
+	}
+
+	var p Pattern
+	switch patternType {
+	case "stripes", "gradient", "rings", "checkers", "blended":
+		if err := n.checkKeys("type", "transform", "a", "b"); err != nil {
+			return nil, err
+		}
+		a, b, err := l.patternPair(n)
+		if err != nil {
+			return nil, err
+		}
+		switch patternType {
+		case "stripes":
+			p = NewStripePattern(a, b)
+		case "gradient":
+			p = NewGradientPattern(a, b)
+		case "rings":
+			p = NewRingPattern(a, b)
+		case "checkers":
+			p = NewCheckersPattern(a, b)
+		case "blended":
+			p = NewBlendedPattern(a, b)
+		}
+	case "perturbed":
+		if err := n.checkKeys("type", "transform", "pattern", "scale", "seed"); err != nil {
+			return nil, err
+		}
+		inner, err := required(n, "pattern")
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := l.pattern(inner)
+		if err != nil {
+			return nil, err
+		}
+		scale := 1.0
+		if node := n.get("scale"); node != nil {
+			if scale, err = node.float(); err != nil {
+				return nil, err
+			}
+		}
+		seed := 0
+		if node := n.get("seed"); node != nil {
+			if seed, err = node.int(); err != nil {
+				return nil, err
+			}
+		}
+		p = NewPerturbedPattern(pattern, scale, int64(seed))
+	case "map":
+		if err := n.checkKeys("type", "transform", "mapping", "uv_pattern"); err != nil {
+			return nil, err
+		}
+		if p, err = l.textureMap(n); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, typeNode.errorf("unknown pattern type %q", patternType)
+	}
+
+	if node := n.get("transform"); node != nil {
+		transform, err := l.transform(node)
+		if err != nil {
+			return nil, err
+		}
+		p.SetTransform(transform)
+	}
+
+	return p, nil
+}
+
+func (l *sceneLoader) patternPair(n *sceneNode) (Pattern, Pattern, error) {
+	patterns := [2]Pattern{}
+	for idx, key := range []string{"a", "b"} {
+		node, err := required(n, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if patterns[idx], err = l.pattern(node); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return patterns[0], patterns[1], nil
+}
+
+func (l *sceneLoader) textureMap(n *sceneNode) (Pattern, error) {
+	mappingNode, err := required(n, "mapping")
+	if err != nil {
+		return nil, err
+	}
+	mappingName, err := mappingNode.str()
+	if err != nil {
+		return nil, err
+	}
+	uvNode, err := required(n, "uv_pattern")
+	if err != nil {
+		return nil, err
+	}
+	uvPattern, err := l.uvPattern(uvNode)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mappingName {
+	case "spherical":
+		return NewTextureMapPattern(uvPattern, SphericalMap), nil
+	case "planar":
+		return NewTextureMapPattern(uvPattern, PlanarMap), nil
+	case "cylindrical":
+		return NewTextureMapPattern(uvPattern, CylindricalMap), nil
+	}
+
+	return nil, mappingNode.errorf("unknown mapping %q", mappingName)
+}
+
+func (l *sceneLoader) uvPattern(n *sceneNode) (UVPattern, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.object(n)
+	if err != nil {
+		return nil, err
+	}
+
+	typeNode, err := required(n, "type")
+	if err != nil {
+		return nil, err
+	}
+	uvType, err := typeNode.str()
+	if err != nil {
+		return nil, err
+	}
+
+	switch uvType {
+	case "checkers":
+		if err := n.checkKeys("type", "width", "height", "a", "b"); err != nil {
+			return nil, err
+		}
+		size := [2]float64{}
+		for idx, key := range []string{"width", "height"} {
+			node, err := required(n, key)
+			if err != nil {
+				return nil, err
+			}
+			if size[idx], err = node.float(); err != nil {
+				return nil, err
+			}
+		}
+		colors := [2]*Color{}
+		for idx, key := range []string{"a", "b"} {
+			node, err := required(n, key)
+			if err != nil {
+				return nil, err
+			}
+			if colors[idx], err = l.color(node); err != nil {
+				return nil, err
+			}
+		}
+		return NewUVCheckers(size[0], size[1], colors[0], colors[1]), nil
+	case "image":
+		if err := n.checkKeys("type", "file", "wrap"); err != nil {
+			return nil, err
+		}
+		fileNode, err := required(n, "file")
+		if err != nil {
+			return nil, err
+		}
+		filename, err := l.path(fileNode)
+		if err != nil {
+			return nil, err
+		}
+		texture, err := LoadImageTexture(filename)
+		if err != nil {
+			return nil, fileNode.errorf("%v", err)
+		}
+		if node := n.get("wrap"); node != nil {
+			wrap, err := node.str()
+			if err != nil {
+				return nil, err
+			}
+			switch wrap {
+			case "repeat":
+				texture.Wrap = WrapRepeat
+			case "clamp":
+				texture.Wrap = WrapClamp
+			default:
+				return nil, node.errorf("unknown wrap mode %q", wrap)
+			}
+		}
+		return texture, nil
+	}
+
+	return nil, typeNode.errorf("unknown uv pattern type %q", uvType)
+}
+
+// shape builds a shape and its children. Shapes without a material of their
+// own inherit the material of the group they are in.
+func (l *sceneLoader) shape(n *sceneNode, inherited *Material) (Shape, error) {
+	release := l.scope()
+	defer release()
+
+	n, err := l.object(n)
+	if err != nil {
+		return nil, err
+	}
+
+	typeNode, err := required(n, "type")
+	if err != nil {
+		return nil, err
+	}
+	shapeType, err := typeNode.str()
+	if err != nil {
+		return nil, err
+	}
+
+	material := inherited
+	if node := n.get("material"); node != nil {
+		if material, err = l.material(node); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := []string{"type", "transform", "material"}
+	var s Shape
+	switch shapeType {
+	case "sphere":
+		s = NewSphere()
+	case "plane":
+		s = NewPlane()
+	case "cube":
+		s = NewCube()
+	case "cylinder", "cone":
+		keys = append(keys, "minimum", "maximum", "closed")
+		minimum, maximum, closed, err := l.truncation(n)
+		if err != nil {
+			return nil, err
+		}
+		if shapeType == "cylinder" {
+			c := NewCylinder()
+			c.Minimum, c.Maximum, c.Closed = minimum, maximum, closed
+			s = c
+		} else {
+			c := NewCone()
+			c.Minimum, c.Maximum, c.Closed = minimum, maximum, closed
+			s = c
+		}
+	case "triangle":
+		keys = append(keys, "p1", "p2", "p3")
+		points := [3]Tuple{}
+		for idx, key := range []string{"p1", "p2", "p3"} {
+			node, err := required(n, key)
+			if err != nil {
+				return nil, err
+			}
+			if points[idx], err = l.point(node); err != nil {
+				return nil, err
+			}
+		}
+		s = NewTriangle(points[0], points[1], points[2])
+	case "group":
+		keys = append(keys, "children", "divide")
+		g := NewGroup()
+		if node := n.get("children"); node != nil {
+			if node, err = l.resolve(node); err != nil {
+				return nil, err
+			}
+			if !node.isArray() {
+				return nil, node.errorf("expected an array")
+			}
+			children := make([]Shape, len(node.array))
+			for idx, childNode := range node.array {
+				if children[idx], err = l.shape(childNode, material); err != nil {
+					return nil, err
+				}
+			}
+			g.AddChild(children...)
+		}
+		s = g
+	case "obj":
+		keys = append(keys, "file", "divide")
+		fileNode, err := required(n, "file")
+		if err != nil {
+			return nil, err
+		}
+		g, err := l.obj(fileNode, material)
+		if err != nil {
+			return nil, err
+		}
+		s = g
+	case "csg":
+		keys = append(keys, "operation", "left", "right")
+		operationNode, err := required(n, "operation")
+		if err != nil {
+			return nil, err
+		}
+		operationName, err := operationNode.str()
+		if err != nil {
+			return nil, err
+		}
+		operations := map[string]CSGOperation{
+			"union":        CSGUnion,
+			"intersection": CSGIntersection,
+			"difference":   CSGDifference,
+		}
+		operation, ok := operations[operationName]
+		if !ok {
+			return nil, operationNode.errorf("unknown operation %q", operationName)
+		}
+		operands := [2]Shape{}
+		for idx, key := range []string{"left", "right"} {
+			node, err := required(n, key)
+			if err != nil {
+				return nil, err
+			}
+			if operands[idx], err = l.shape(node, material); err != nil {
+				return nil, err
+			}
+		}
+		s = NewCSG(operation, operands[0], operands[1])
+	default:
+		return nil, typeNode.errorf("unknown shape type %q", shapeType)
+	}
+
+	if err := n.checkKeys(keys...); err != nil {
+		return nil, err
+	}
+
+	if material != nil {
+		s.SetMaterial(material)
+	}
+
+	if node := n.get("transform"); node != nil {
+		transform, err := l.transform(node)
+		if err != nil {
+			return nil, err
+		}
+		s.SetTransform(transform)
+	}
+
+	if node := n.get("divide"); node != nil {
+		threshold, err := node.int()
+		if err != nil {
+			return nil, err
+		}
+		if threshold < 0 {
+			return nil, node.errorf("divide must not be negative")
+		}
+		s.Divide(threshold)
+	}
+
+	return s, nil
+}
+
+func (l *sceneLoader) truncation(n *sceneNode) (float64, float64, bool, error) {
+	minimum, maximum := math.Inf(-1), math.Inf(1)
+	closed := false
+
+	var err error
+	if node := n.get("minimum"); node != nil {
+		if minimum, err = node.float(); err != nil {
+			return 0, 0, false, err
+		}
+	}
+	if node := n.get("maximum"); node != nil {
+		if maximum, err = node.float(); err != nil {
+			return 0, 0, false, err
+		}
+	}
+	if node := n.get("closed"); node != nil {
+		if closed, err = node.bool(); err != nil {
+			return 0, 0, false, err
+		}
+	}
+
+	return minimum, maximum, closed, nil
+}
+
+func (l *sceneLoader) obj(fileNode *sceneNode, material *Material) (*Group, error) {
+	filename, err := l.path(fileNode)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fileNode.errorf("%v", err)
+	}
+	defer f.Close()
+
+	obj, err := ParseObjFile(f)
+	if err != nil {
+		return nil, fileNode.errorf("%v", err)
+	}
+
+	g := obj.ToGroup()
+	if material != nil {
+		setGroupMaterial(g, material)
+	}
+
+	return g, nil
+}
+
+func setGroupMaterial(g *Group, material *Material) {
+	for _, child := range g.Children {
+		if subgroup, ok := child.(*Group); ok {
+			setGroupMaterial(subgroup, material)
+		} else {
+			child.SetMaterial(material)
+		}
+	}
+}
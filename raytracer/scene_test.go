@@ -0,0 +1,280 @@
+package raytracer_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+const testScene = `{
+  "camera": {
+    "width": 100,
+    "height": 50,
+    "field_of_view": 0.785,
+    "from": [0, 0, -5],
+    "to": [0, 0, 0]
+  },
+  "lights": [
+    { "position": [-10, 10, -10], "intensity": [1, 1, 1] }
+  ],
+  "max_depth": 3,
+  "define": {
+    "shiny": { "color": [1, 0, 0], "specular": 0.2, "reflective": 0.5 },
+    "shinier": { "extends": "shiny", "reflective": 0.8 },
+    "move-up": [["translate", 0, 1, 0]]
+  },
+  "shapes": [
+    {
+      "type": "sphere",
+      "material": "shinier",
+      "transform": [["scale", 2, 2, 2], "move-up"]
+    },
+    {
+      "type": "group",
+      "material": { "color": [0, 0, 1] },
+      "children": [
+        { "type": "cube" },
+        { "type": "cylinder", "minimum": 0, "maximum": 2, "closed": true, "material": "shiny" }
+      ]
+    }
+  ]
+}`
+
+func TestParseScene(t *testing.T) {
+	scene, err := rt.ParseScene(strings.NewReader(testScene))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	camera := scene.Camera
+	if camera.HSize != 100 || camera.VSize != 50 || camera.FieldOfView != 0.785 {
+		t.Errorf("Error: %v", camera)
+	}
+
+	if !camera.Transform.Equals(rt.ViewTransform(rt.NewPoint(0, 0, -5), rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0))) {
+		t.Errorf("Error: %v", camera.Transform)
+	}
+
+	world := scene.World
+	if world.MaxDepth != 3 || len(world.Lights) != 1 || len(world.Objects) != 2 {
+		t.Fatalf("Error: %v", world)
+	}
+
+	if !world.Lights[0].Position.Equals(rt.NewPoint(-10, 10, -10)) {
+		t.Errorf("Error: %v", world.Lights[0].Position)
+	}
+}
+
+func TestParseSceneDefinitions(t *testing.T) {
+	scene, err := rt.ParseScene(strings.NewReader(testScene))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	sphere := scene.World.Objects[0]
+
+	expected := rt.NewMaterial()
+	expected.Color = &rt.Color{1, 0, 0}
+	expected.Specular = 0.2
+	expected.Reflective = 0.8
+	if !sphere.GetMaterial().Equals(expected) {
+		t.Errorf("Error: %v", sphere.GetMaterial())
+	}
+
+	// The scaling is listed first so it is applied before the translation.
+	transform := rt.Translation(0, 1, 0).Mul(rt.Scaling(2, 2, 2))
+	if !sphere.GetTransform().Equals(transform) {
+		t.Errorf("Error: %v", sphere.GetTransform())
+	}
+}
+
+func TestParseSceneGroupMaterial(t *testing.T) {
+	scene, err := rt.ParseScene(strings.NewReader(testScene))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	g := scene.World.Objects[1].(*rt.Group)
+	if len(g.Children) != 2 {
+		t.Fatalf("Error: %v", g.Children)
+	}
+
+	if !g.Children[0].GetMaterial().Color.Equals(&rt.Color{0, 0, 1}) {
+		t.Errorf("Error: %v", g.Children[0].GetMaterial())
+	}
+
+	cylinder := g.Children[1].(*rt.Cylinder)
+	if !cylinder.Material.Color.Equals(&rt.Color{1, 0, 0}) {
+		t.Errorf("Error: %v", cylinder.Material)
+	}
+
+	if cylinder.Minimum != 0 || cylinder.Maximum != 2 || !cylinder.Closed {
+		t.Errorf("Error: %v", cylinder)
+	}
+}
+
+func TestParseScenePatterns(t *testing.T) {
+	scene, err := rt.ParseScene(strings.NewReader(`{
+  "camera": { "width": 10, "height": 10, "field_of_view": 1, "from": [0, 0, -5], "to": [0, 0, 0] },
+  "shapes": [
+    {
+      "type": "plane",
+      "material": {
+        "pattern": {
+          "type": "checkers",
+          "a": { "type": "stripes", "a": [1, 1, 1], "b": [0, 0, 0] },
+          "b": [0, 0, 1],
+          "transform": [["scale", 2, 2, 2]]
+        }
+      }
+    }
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	pattern := scene.World.Objects[0].GetMaterial().Pattern.(*rt.CheckersPattern)
+
+	if !pattern.GetTransform().Equals(rt.Scaling(2, 2, 2)) {
+		t.Errorf("Error: %v", pattern.GetTransform())
+	}
+
+	if _, ok := pattern.A.(*rt.StripePattern); !ok {
+		t.Errorf("Error: %v", pattern.A)
+	}
+
+	if c := pattern.Sample(rt.NewPoint(2.5, 0, 0)); !c.Equals(&rt.Color{0, 0, 1}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestParseSceneCSG(t *testing.T) {
+	scene, err := rt.ParseScene(strings.NewReader(`{
+  "camera": { "width": 10, "height": 10, "field_of_view": 1, "from": [0, 0, -5], "to": [0, 0, 0] },
+  "shapes": [
+    {
+      "type": "csg",
+      "operation": "difference",
+      "left": { "type": "cube" },
+      "right": { "type": "sphere", "transform": [["scale", 1.3, 1.3, 1.3]] }
+    }
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	csg := scene.World.Objects[0].(*rt.CSG)
+	if csg.Operation != rt.CSGDifference {
+		t.Errorf("Error: %v", csg.Operation)
+	}
+
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	if xs := csg.Intersect(r); len(xs) != 0 {
+		t.Errorf("Error: %v", xs)
+	}
+}
+
+func TestLoadSceneObjFile(t *testing.T) {
+	dir := t.TempDir()
+
+	obj := "v -1 1 0\nv -1 0 0\nv 1 0 0\nv 1 1 0\nf 1 2 3 4\n"
+	if err := os.WriteFile(filepath.Join(dir, "quad.obj"), []byte(obj), 0644); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	scene := `{
+  "camera": { "width": 10, "height": 10, "field_of_view": 1, "from": [0, 0, -5], "to": [0, 0, 0] },
+  "shapes": [
+    { "type": "obj", "file": "quad.obj", "material": { "color": [0, 1, 0] } }
+  ]
+}`
+	filename := filepath.Join(dir, "scene.json")
+	if err := os.WriteFile(filename, []byte(scene), 0644); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	loaded, err := rt.LoadScene(filename)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	g := loaded.World.Objects[0].(*rt.Group)
+	if len(g.Children) != 2 {
+		t.Fatalf("Error: %v", g.Children)
+	}
+
+	for _, child := range g.Children {
+		if !child.GetMaterial().Color.Equals(&rt.Color{0, 1, 0}) {
+			t.Errorf("Error: %v", child.GetMaterial())
+		}
+	}
+}
+
+func TestLoadSceneExamples(t *testing.T) {
+	filenames, err := filepath.Glob("../scenes/*.json")
+	if err != nil || len(filenames) == 0 {
+		t.Fatalf("Error: %v %v", filenames, err)
+	}
+
+	for _, filename := range filenames {
+		if _, err := rt.LoadScene(filename); err != nil {
+			t.Errorf("Error: %v", err)
+		}
+	}
+}
+
+func TestParseSceneErrors(t *testing.T) {
+	camera := `"camera": { "width": 10, "height": 10, "field_of_view": 1, "from": [0, 0, -5], "to": [0, 0, 0] }`
+
+	examples := []struct {
+		scene    string
+		expected string
+	}{
+		{"{\n" + camera + ",\n\"shapes\": [\n}", "scene line 4:"},
+		{"{\n" + camera + "\n}\n{}", "scene line 4: unexpected data"},
+		{"{\n\"camera\": {}\n}", "scene line 2, camera: missing width"},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"teapot\" }\n]\n}", "scene line 4, shapes[0].type: unknown shape type \"teapot\""},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"sphere\",\n\"colour\": [1, 0, 0] }\n]\n}", "scene line 5, shapes[0].colour: unknown key"},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"sphere\",\n\"material\": \"red\" }\n]\n}", "scene line 5, shapes[0].material: undefined name \"red\""},
+		{"{\n" + camera + ",\n\"define\": { \"a\": \"b\",\n\"b\": \"a\" },\n\"shapes\": [\"a\"]\n}", "scene line 4, define.b: definition \"a\" refers to itself"},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"sphere\",\n\"transform\": [[\"twist\", 1]] }\n]\n}", "scene line 5, shapes[0].transform[0][0]: unknown transformation \"twist\""},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"sphere\",\n\"material\": { \"color\": [1, 0] } }\n]\n}", "scene line 5, shapes[0].material.color: expected an array of 3 numbers"},
+		{"{\n" + camera + ",\n\"lights\": [\n{ \"position\": [0, 0, \"up\"] }\n]\n}", "scene line 4, lights[0].position[2]: expected a number"},
+		{"{\n" + camera + ",\n\"lights\": [\n{ \"position\": [0, 0, 1e999] }\n]\n}", "scene line 4, lights[0].position[2]: strconv.ParseFloat"},
+		{"{\n" + camera + ",\n\"define\": { \"t\": [\"t\"] },\n\"shapes\": [{ \"type\": \"sphere\", \"transform\": \"t\" }]\n}", "scene line 3, define.t[0]: definition \"t\" refers to itself"},
+		{"{\n" + camera + ",\n\"define\": { \"g\": { \"type\": \"group\", \"children\": [\"g\"] } },\n\"shapes\": [\"g\"]\n}", "scene line 3, define.g.children[0]: definition \"g\" refers to itself"},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"sphere\",\n\"transform\": [[\"scale\", 0, 1, 1]] }\n]\n}", "scene line 5, shapes[0].transform: transform is not invertible"},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"sphere\",\n\"transform\": [[\"scale\", 0.001, 0.001, 1]] }\n]\n}", "scene line 5, shapes[0].transform: transform is not invertible"},
+		{"{\n" + camera + ",\n\"shapes\": [\n{ \"type\": \"group\",\n\"divide\": -1 }\n]\n}", "scene line 5, shapes[0].divide: divide must not be negative"},
+		{"{\n\"camera\": { \"width\": 10, \"height\": 10, \"field_of_view\": 1, \"from\": [0, 0, 1], \"to\": [0, 0, 1] }\n}", "scene line 2, camera.to: camera looks from and to the same point"},
+		{"{\n\"camera\": { \"width\": 10, \"height\": 10, \"field_of_view\": 3.2, \"from\": [0, 0, 1], \"to\": [0, 0, 0] }\n}", "scene line 2, camera.field_of_view: field of view must be between 0 and pi radians"},
+		{"{\n\"camera\": { \"width\": 10, \"height\": 10, \"field_of_view\": 0, \"from\": [0, 0, 1], \"to\": [0, 0, 0] }\n}", "scene line 2, camera.field_of_view: field of view must be between 0 and pi radians"},
+		{"{\n\"camera\": { \"width\": 10, \"height\": 10, \"field_of_view\": 1, \"from\": [0, 0, 1], \"to\": [0, 0, 0], \"up\": [0, 0, 0] }\n}", "scene line 2, camera: up must not be zero or parallel to the view direction"},
+	}
+
+	for _, example := range examples {
+		_, err := rt.ParseScene(strings.NewReader(example.scene))
+
+		if err == nil || !strings.HasPrefix(err.Error(), example.expected) {
+			t.Errorf("Error: %v, expected %v", err, example.expected)
+		}
+	}
+}
+
+func TestRenderScene(t *testing.T) {
+	scene, err := rt.LoadScene("../scenes/ball.json")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	c := scene.Camera.Render(scene.World).GetAt(50, 50)
+	if math.Abs(c.R-c.B) > 0.01 || c.G >= c.R {
+		t.Errorf("Error: %v", c)
+	}
+}
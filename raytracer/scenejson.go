@@ -0,0 +1,233 @@
+package raytracer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// sceneNode is a parsed JSON value that remembers where it came from, so the
+// scene loader can point at the offending line and key when it rejects one.
+type sceneNode struct {
+	Path string
+	Line int
+
+	value  interface{}
+	keys   []string
+	object map[string]*sceneNode
+	array  []*sceneNode
+}
+
+func parseSceneNodes(data []byte) (*sceneNode, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	root, err := parseSceneNode(dec, data, "")
+	if err != nil {
+		return nil, err
+	}
+
+	offset := skipJSONSpace(data, int(dec.InputOffset()))
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("scene line %d: unexpected data after the scene", lineAt(data, offset))
+	}
+
+	return root, nil
+}
+
+func parseSceneNode(dec *json.Decoder, data []byte, path string) (*sceneNode, error) {
+	offset := skipJSONSpace(data, int(dec.InputOffset()))
+	token, err := dec.Token()
+	if err != nil {
+		return nil, jsonSyntaxError(data, dec, err)
+	}
+
+	node := &sceneNode{Path: path, Line: lineAt(data, offset)}
+
+	switch delim := token.(type) {
+	case json.Delim:
+		if delim == '{' {
+			node.object = map[string]*sceneNode{}
+			for dec.More() {
+				keyOffset := skipJSONSpace(data, int(dec.InputOffset()))
+				token, err := dec.Token()
+				if err != nil {
+					return nil, jsonSyntaxError(data, dec, err)
+				}
+				key := token.(string)
+				if _, ok := node.object[key]; ok {
+					return nil, fmt.Errorf("scene line %d, %s: duplicate key", lineAt(data, keyOffset), joinScenePath(path, key))
+				}
+
+				child, err := parseSceneNode(dec, data, joinScenePath(path, key))
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, key)
+				node.object[key] = child
+			}
+		} else {
+			node.array = []*sceneNode{}
+			for dec.More() {
+				child, err := parseSceneNode(dec, data, fmt.Sprintf("%s[%d]", path, len(node.array)))
+				if err != nil {
+					return nil, err
+				}
+				node.array = append(node.array, child)
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			return nil, jsonSyntaxError(data, dec, err)
+		}
+	default:
+		node.value = token
+	}
+
+	return node, nil
+}
+
+func jsonSyntaxError(data []byte, dec *json.Decoder, err error) error {
+	offset := int(dec.InputOffset())
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		offset = int(syntaxErr.Offset)
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return fmt.Errorf("scene line %d: %v", lineAt(data, offset), err)
+}
+
+func skipJSONSpace(data []byte, offset int) int {
+	for offset < len(data) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+
+	return offset
+}
+
+func lineAt(data []byte, offset int) int {
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+func joinScenePath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func (n *sceneNode) errorf(format string, args ...interface{}) error {
+	if n.Path == "" {
+		return fmt.Errorf("scene line %d: %s", n.Line, fmt.Sprintf(format, args...))
+	}
+
+	return fmt.Errorf("scene line %d, %s: %s", n.Line, n.Path, fmt.Sprintf(format, args...))
+}
+
+func (n *sceneNode) isObject() bool {
+	return n.object != nil
+}
+
+func (n *sceneNode) isArray() bool {
+	return n.array != nil
+}
+
+// get returns the value stored under key, or nil when n has no such key.
+func (n *sceneNode) get(key string) *sceneNode {
+	return n.object[key]
+}
+
+// checkKeys rejects keys that are not in allowed, which catches typos that
+// would otherwise be silently ignored.
+func (n *sceneNode) checkKeys(allowed ...string) error {
+	for _, key := range n.keys {
+		found := false
+		for _, name := range allowed {
+			if key == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return n.object[key].errorf("unknown key")
+		}
+	}
+
+	return nil
+}
+
+func (n *sceneNode) str() (string, error) {
+	s, ok := n.value.(string)
+	if !ok {
+		return "", n.errorf("expected a string")
+	}
+
+	return s, nil
+}
+
+func (n *sceneNode) float() (float64, error) {
+	number, ok := n.value.(json.Number)
+	if !ok {
+		return 0, n.errorf("expected a number")
+	}
+
+	f, err := number.Float64()
+	if err != nil {
+		return 0, n.errorf("%v", err)
+	}
+
+	return f, nil
+}
+
+func (n *sceneNode) int() (int, error) {
+	number, ok := n.value.(json.Number)
+	if !ok {
+		return 0, n.errorf("expected an integer")
+	}
+
+	i, err := number.Int64()
+	if err != nil {
+		return 0, n.errorf("expected an integer")
+	}
+
+	return int(i), nil
+}
+
+func (n *sceneNode) bool() (bool, error) {
+	b, ok := n.value.(bool)
+	if !ok {
+		return false, n.errorf("expected true or false")
+	}
+
+	return b, nil
+}
+
+func (n *sceneNode) floats(count int) ([]float64, error) {
+	if len(n.array) != count {
+		return nil, n.errorf("expected an array of %d numbers", count)
+	}
+
+	values := make([]float64, count)
+	for idx, element := range n.array {
+		value, err := element.float()
+		if err != nil {
+			return nil, err
+		}
+		values[idx] = value
+	}
+
+	return values, nil
+}
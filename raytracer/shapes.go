@@ -0,0 +1,154 @@
+package raytracer
+
+type Shape interface {
+	Intersect(r Ray) Intersections
+	AppendIntersections(xs Intersections, r Ray) Intersections
+	NormalAt(p Tuple) Tuple
+	NormalAtHit(p Tuple, hit *Intersection) Tuple
+	LocalIntersect(r Ray) Intersections
+	AppendLocalIntersections(xs Intersections, r Ray) Intersections
+	LocalNormalAt(p Tuple) Tuple
+	Bounds() *BoundingBox
+	ParentSpaceBounds() *BoundingBox
+	Divide(threshold int)
+	GetTransform() Matrix
+	SetTransform(transform Matrix)
+	GetMaterial() *Material
+	SetMaterial(material *Material)
+	GetParent() Shape
+	SetParent(parent Shape)
+	Includes(other Shape) bool
+	WorldToObject(p Tuple) Tuple
+	NormalToWorld(n Tuple) Tuple
+}
+
+// hitNormaler is implemented by shapes whose normal depends on where exactly
+// they were hit, such as smooth triangles interpolating vertex normals.
+type hitNormaler interface {
+	LocalNormalAtHit(p Tuple, hit *Intersection) Tuple
+}
+
+// boundsCache is implemented by composite shapes that cache the bounds of
+// their children and must recompute them when a descendant changes.
+type boundsCache interface {
+	updateBounds()
+}
+
+func updateParentBounds(parent Shape) {
+	if cache, ok := parent.(boundsCache); ok {
+		cache.updateBounds()
+	}
+}
+
+// shape holds the state shared by all shapes and converts between world and
+// object space, delegating the actual geometry to the concrete shape in local.
+type shape struct {
+	Transform Matrix
+	Material  *Material
+	Parent    Shape
+
+	inverse          Matrix
+	inverseTranspose Matrix
+	local            Shape
+}
+
+func newShape(local Shape) shape {
+	return shape{
+		Transform:        Identity(),
+		Material:         NewMaterial(),
+		inverse:          Identity(),
+		inverseTranspose: Identity(),
+		local:            local,
+	}
+}
+
+func (s *shape) GetTransform() Matrix {
+	return s.Transform
+}
+
+func (s *shape) SetTransform(transform Matrix) {
+	s.Transform = transform
+	s.inverse = transform.Inv()
+	s.inverseTranspose = s.inverse.Trans()
+
+	if s.Parent != nil {
+		updateParentBounds(s.Parent)
+	}
+}
+
+func (s *shape) ParentSpaceBounds() *BoundingBox {
+	return s.local.Bounds().Transform(s.Transform)
+}
+
+func (s *shape) Divide(threshold int) {}
+
+func (s *shape) GetMaterial() *Material {
+	return s.Material
+}
+
+func (s *shape) SetMaterial(material *Material) {
+	s.Material = material
+}
+
+func (s *shape) GetParent() Shape {
+	return s.Parent
+}
+
+func (s *shape) SetParent(parent Shape) {
+	s.Parent = parent
+}
+
+func (s *shape) Includes(other Shape) bool {
+	return s.local == other
+}
+
+func (s *shape) WorldToObject(p Tuple) Tuple {
+	if s.Parent != nil {
+		p = s.Parent.WorldToObject(p)
+	}
+
+	return s.inverse.MulT(p)
+}
+
+func (s *shape) NormalToWorld(n Tuple) Tuple {
+	normal := s.inverseTranspose.MulT(n)
+	normal.W = 0
+	normal = normal.Norm()
+
+	if s.Parent != nil {
+		normal = s.Parent.NormalToWorld(normal)
+	}
+
+	return normal
+}
+
+func (s *shape) Intersect(r Ray) Intersections {
+	return s.AppendIntersections(NewIntersections(), r)
+}
+
+// AppendIntersections appends the intersections of r with the shape to xs.
+// Reusing xs between rays avoids allocating on every intersection test.
+func (s *shape) AppendIntersections(xs Intersections, r Ray) Intersections {
+	return s.local.AppendLocalIntersections(xs, r.Transform(s.inverse))
+}
+
+func (s *shape) LocalIntersect(r Ray) Intersections {
+	return s.local.AppendLocalIntersections(NewIntersections(), r)
+}
+
+func (s *shape) NormalAt(p Tuple) Tuple {
+	return s.NormalAtHit(p, nil)
+}
+
+func (s *shape) NormalAtHit(p Tuple, hit *Intersection) Tuple {
+	localPoint := s.WorldToObject(p)
+
+	var localNormal Tuple
+	if h, ok := s.local.(hitNormaler); ok && hit != nil {
+		localNormal = h.LocalNormalAtHit(localPoint, hit)
+	} else {
+		localNormal = s.local.LocalNormalAt(localPoint)
+	}
+
+	return s.NormalToWorld(localNormal)
+}
@@ -0,0 +1,93 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestShapeDefaultTransformation(t *testing.T) {
+	/* Scenario: The default transformation
+	   Given s ← test_shape()
+	   Then s.transform = identity_matrix */
+	var s rt.Shape = rt.NewSphere()
+
+	if !s.GetTransform().Equals(rt.Identity()) {
+		t.Errorf("Error: %v", s.GetTransform())
+	}
+}
+
+func TestShapeAssignTransformation(t *testing.T) {
+	/* Scenario: Assigning a transformation
+	   Given s ← test_shape()
+	   When set_transform(s, translation(2, 3, 4))
+	   Then s.transform = translation(2, 3, 4) */
+	var s rt.Shape = rt.NewSphere()
+
+	s.SetTransform(rt.Translation(2, 3, 4))
+
+	if !s.GetTransform().Equals(rt.Translation(2, 3, 4)) {
+		t.Errorf("Error: %v", s.GetTransform())
+	}
+}
+
+func TestShapeAssignMaterial(t *testing.T) {
+	/* Scenario: Assigning a material
+	   Given s ← test_shape()
+	     And m ← material()
+	     And m.ambient ← 1
+	   When s.material ← m
+	   Then s.material = m */
+	var s rt.Shape = rt.NewSphere()
+	m := rt.NewMaterial()
+	m.Ambient = 1
+
+	s.SetMaterial(m)
+
+	if s.GetMaterial() != m {
+		t.Errorf("Error: %v", s.GetMaterial())
+	}
+}
+
+func TestShapeIntersectUsesObjectSpace(t *testing.T) {
+	/* Scenario: Intersecting a scaled shape with a ray
+	   Given r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And s ← test_shape()
+	   When set_transform(s, scaling(2, 2, 2))
+	     And xs ← intersect(s, r)
+	   Then xs match local_intersect(s, ray(point(0, 0, -2.5), vector(0, 0, 0.5))) */
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	var s rt.Shape = rt.NewSphere()
+
+	s.SetTransform(rt.Scaling(2, 2, 2))
+	xs := s.Intersect(r)
+	local := s.LocalIntersect(rt.NewRay(rt.NewPoint(0, 0, -2.5), rt.NewVector(0, 0, 0.5)))
+
+	if len(xs) != len(local) {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	for idx := range xs {
+		if xs[idx].T != local[idx].T {
+			t.Errorf("Error: %v", xs[idx].T)
+		}
+	}
+}
+
+func TestShapeNormalOnTransformedShape(t *testing.T) {
+	/* Scenario: Computing the normal on a transformed shape
+	   Given s ← test_shape()
+	     And m ← scaling(1, 0.5, 1) * rotation_z(π/5)
+	     And set_transform(s, m)
+	   When n ← normal_at(s, point(0, √2/2, -√2/2))
+	   Then n = vector(0, 0.97014, -0.24254) */
+	var s rt.Shape = rt.NewSphere()
+	s.SetTransform(rt.Scaling(1, 0.5, 1).Mul(rt.RotationZ(math.Pi / 5)))
+
+	n := s.NormalAt(rt.NewPoint(0, math.Sqrt(2)/2, -math.Sqrt(2)/2))
+
+	if !n.Equals(rt.NewVector(0, 0.97014, -0.24254)) {
+		t.Errorf("Error: %v", n)
+	}
+}
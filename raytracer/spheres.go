@@ -3,50 +3,40 @@ package raytracer
 import "math"
 
 type Sphere struct {
-	Transform Matrix
-	Material  *Material
+	shape
 }
 
 func NewSphere() *Sphere {
-	return &Sphere{Identity(), NewMaterial()}
+	s := &Sphere{}
+	s.shape = newShape(s)
+	return s
 }
 
-func (s *Sphere) SetTransform(transform Matrix) {
-	s.Transform = transform
-}
-
-func (s *Sphere) Intersect(r *Ray) Intersections {
-	transformedRay := r.Transform(s.Transform.Inv())
-
-	sphereToRay := transformedRay.Origin.Sub(NewPoint(0, 0, 0))
+func (s *Sphere) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	sphereToRay := r.Origin.Sub(NewPoint(0, 0, 0))
 
-	a := transformedRay.Direction.Dot(transformedRay.Direction)
-	b := 2 * transformedRay.Direction.Dot(sphereToRay)
+	a := r.Direction.Dot(r.Direction)
+	b := 2 * r.Direction.Dot(sphereToRay)
 	c := sphereToRay.Dot(sphereToRay) - 1
 
 	disc := math.Pow(b, 2) - 4*a*c
 	if disc < 0 {
-		return NewIntersections()
+		return xs
 	}
 
 	t1 := (-b - math.Sqrt(disc)) / (2 * a)
 	t2 := (-b + math.Sqrt(disc)) / (2 * a)
 
-	intersections := NewIntersections(
+	return append(xs,
 		NewIntersection(t1, s),
 		NewIntersection(t2, s),
 	)
-	return intersections
 }
 
-func (s *Sphere) NormalAt(p *Tuple) *Tuple {
-	objPoint := s.Transform.Inv().MulT(p)
-	objNormal := objPoint.Sub(NewPoint(0, 0, 0))
-	worldNormal := s.Transform.Inv().Trans().MulT(objNormal)
-	worldNormal.W = 0
-	return worldNormal.Norm()
+func (s *Sphere) LocalNormalAt(p Tuple) Tuple {
+	return p.Sub(NewPoint(0, 0, 0))
 }
 
-func (s *Sphere) GetMaterial() *Material {
-	return s.Material
+func (s *Sphere) Bounds() *BoundingBox {
+	return NewBoundingBox(NewPoint(-1, -1, -1), NewPoint(1, 1, 1))
 }
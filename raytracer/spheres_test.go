@@ -353,3 +353,27 @@ func TestSphereAssignedMaterial(t *testing.T) {
 		t.Errorf("Error: %v", s.Material)
 	}
 }
+
+func newGlassSphere() *rt.Sphere {
+	s := rt.NewSphere()
+	s.Material.Transparency = 1.0
+	s.Material.RefractiveIndex = 1.5
+	return s
+}
+
+func TestGlassSphere(t *testing.T) {
+	/* Scenario: A helper for producing a sphere with a glassy material
+	   Given s ← glass_sphere()
+	   Then s.transform = identity_matrix
+	     And s.material.transparency = 1.0
+	     And s.material.refractive_index = 1.5 */
+	s := newGlassSphere()
+
+	if !s.Transform.Equals(rt.Identity()) {
+		t.Errorf("Error: %v", s.Transform)
+	}
+
+	if s.Material.Transparency != 1.0 || s.Material.RefractiveIndex != 1.5 {
+		t.Errorf("Error: %v", s.Material)
+	}
+}
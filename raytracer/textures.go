@@ -0,0 +1,107 @@
+package raytracer
+
+import (
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+)
+
+type WrapMode int
+
+const (
+	WrapRepeat WrapMode = iota
+	WrapClamp
+)
+
+// ImageTexture is a UV pattern backed by an image, sampled with bilinear
+// filtering between the centers of neighbouring pixels.
+type ImageTexture struct {
+	Width, Height int
+	Wrap          WrapMode
+
+	pixels []*Color
+}
+
+// NewImageTexture copies the pixels of img into a texture. Images without
+// any pixels have nothing to sample and are rejected.
+func NewImageTexture(img image.Image) (*ImageTexture, error) {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return nil, errors.New("texture image is empty")
+	}
+
+	t := &ImageTexture{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+		pixels: make([]*Color, bounds.Dx()*bounds.Dy()),
+	}
+
+	for y := 0; y < t.Height; y++ {
+		for x := 0; x < t.Width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			t.pixels[y*t.Width+x] = &Color{float64(r) / 0xffff, float64(g) / 0xffff, float64(b) / 0xffff}
+		}
+	}
+
+	return t, nil
+}
+
+func LoadImageTexture(filename string) (*ImageTexture, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewImageTexture(img)
+}
+
+func wrapIndex(idx, size int, mode WrapMode) int {
+	if mode == WrapClamp {
+		if idx < 0 {
+			return 0
+		}
+		if idx >= size {
+			return size - 1
+		}
+		return idx
+	}
+
+	idx %= size
+	if idx < 0 {
+		idx += size
+	}
+	return idx
+}
+
+// PixelAt returns the pixel at x, y, with out of range coordinates resolved
+// according to the texture's wrap mode.
+func (t *ImageTexture) PixelAt(x, y int) *Color {
+	x = wrapIndex(x, t.Width, t.Wrap)
+	y = wrapIndex(y, t.Height, t.Wrap)
+
+	return t.pixels[y*t.Width+x]
+}
+
+func (t *ImageTexture) UVPatternAt(u, v float64) *Color {
+	// v runs bottom to top while image rows run top to bottom
+	x := u*float64(t.Width) - 0.5
+	y := (1-v)*float64(t.Height) - 0.5
+
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	ix, iy := int(x0), int(y0)
+
+	top := t.PixelAt(ix, iy).Mul(1 - fx).Add(t.PixelAt(ix+1, iy).Mul(fx))
+	bottom := t.PixelAt(ix, iy+1).Mul(1 - fx).Add(t.PixelAt(ix+1, iy+1).Mul(fx))
+
+	return top.Mul(1 - fy).Add(bottom.Mul(fy))
+}
@@ -0,0 +1,159 @@
+package raytracer_test
+
+import (
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+// testTexture returns a 2x2 texture with a black and a white pixel on top and
+// a white and a black pixel below.
+func testTexture() *rt.ImageTexture {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.Black)
+	img.Set(1, 0, color.White)
+	img.Set(0, 1, color.White)
+	img.Set(1, 1, color.Black)
+
+	texture, _ := rt.NewImageTexture(img)
+	return texture
+}
+
+func TestImageTexturePixelCenters(t *testing.T) {
+	/* Scenario: Sampling an image texture at pixel centers
+	   Given texture ← a 2x2 image [black, white; white, black]
+	   Then uv_pattern_at(texture, 0.25, 0.75) = black
+	     And uv_pattern_at(texture, 0.75, 0.75) = white
+	     And uv_pattern_at(texture, 0.25, 0.25) = white
+	     And uv_pattern_at(texture, 0.75, 0.25) = black */
+	texture := testTexture()
+
+	examples := []struct {
+		u, v     float64
+		expected *rt.Color
+	}{
+		{0.25, 0.75, black},
+		{0.75, 0.75, white},
+		{0.25, 0.25, white},
+		{0.75, 0.25, black},
+	}
+
+	for _, example := range examples {
+		if c := texture.UVPatternAt(example.u, example.v); !c.Equals(example.expected) {
+			t.Errorf("Error: %v %v %v", example.u, example.v, c)
+		}
+	}
+}
+
+func TestImageTextureBilinear(t *testing.T) {
+	/* Scenario: Sampling between pixel centers blends the neighbours
+	   Given texture ← a 2x2 image [black, white; white, black]
+	   Then uv_pattern_at(texture, 0.5, 0.75) = color(0.5, 0.5, 0.5)
+	     And uv_pattern_at(texture, 0.5, 0.5) = color(0.5, 0.5, 0.5)
+	     And uv_pattern_at(texture, 0.375, 0.75) = color(0.25, 0.25, 0.25) */
+	texture := testTexture()
+
+	examples := []struct {
+		u, v     float64
+		expected *rt.Color
+	}{
+		{0.5, 0.75, &rt.Color{0.5, 0.5, 0.5}},
+		{0.5, 0.5, &rt.Color{0.5, 0.5, 0.5}},
+		{0.375, 0.75, &rt.Color{0.25, 0.25, 0.25}},
+	}
+
+	for _, example := range examples {
+		if c := texture.UVPatternAt(example.u, example.v); !c.Equals(example.expected) {
+			t.Errorf("Error: %v %v %v", example.u, example.v, c)
+		}
+	}
+}
+
+func TestImageTextureWrapModes(t *testing.T) {
+	/* Scenario: Sampling at the edge either wraps around or clamps
+	   Given texture ← a 2x2 image [black, white; white, black]
+	   When texture.wrap ← repeat
+	   Then uv_pattern_at(texture, 0, 0.75) = color(0.5, 0.5, 0.5)
+	   When texture.wrap ← clamp
+	   Then uv_pattern_at(texture, 0, 0.75) = black */
+	texture := testTexture()
+
+	texture.Wrap = rt.WrapRepeat
+	if c := texture.UVPatternAt(0, 0.75); !c.Equals(&rt.Color{0.5, 0.5, 0.5}) {
+		t.Errorf("Error: %v", c)
+	}
+
+	texture.Wrap = rt.WrapClamp
+	if c := texture.UVPatternAt(0, 0.75); !c.Equals(black) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestLoadImageTexture(t *testing.T) {
+	/* Scenario: Loading PNG and JPEG textures from disk */
+	img := image.NewRGBA(image.Rect(0, 0, 8, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{255, 0, 0, 255})
+		}
+	}
+
+	dir := t.TempDir()
+
+	encoders := map[string]func(f *os.File) error{
+		"texture.png": func(f *os.File) error { return png.Encode(f, img) },
+		"texture.jpg": func(f *os.File) error { return jpeg.Encode(f, img, &jpeg.Options{Quality: 100}) },
+	}
+
+	for name, encode := range encoders {
+		filename := filepath.Join(dir, name)
+		f, err := os.Create(filename)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if err := encode(f); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		f.Close()
+
+		texture, err := rt.LoadImageTexture(filename)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		if texture.Width != 8 || texture.Height != 4 {
+			t.Errorf("Error: %v %v", texture.Width, texture.Height)
+		}
+
+		c := texture.UVPatternAt(0.5, 0.5)
+		if c.R < 0.95 || c.G > 0.05 || c.B > 0.05 {
+			t.Errorf("Error: %v %v", name, c)
+		}
+	}
+}
+
+func TestNewImageTextureEmpty(t *testing.T) {
+	/* Scenario: An image without pixels can't be used as a texture
+	   Given img ← a 0x0 image
+	   Then image_texture(img) fails */
+	if _, err := rt.NewImageTexture(image.NewRGBA(image.Rect(0, 0, 0, 0))); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+	if _, err := rt.NewImageTexture(image.NewRGBA(image.Rect(0, 0, 4, 0))); err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
+
+func TestLoadImageTextureMissing(t *testing.T) {
+	_, err := rt.LoadImageTexture("does-not-exist.png")
+
+	if err == nil {
+		t.Errorf("Error: %v", err)
+	}
+}
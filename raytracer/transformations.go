@@ -69,3 +69,18 @@ func Shearing(xy, xz, yx, yz, zx, zy float64) Matrix {
 
 	return matrix
 }
+
+func ViewTransform(from, to, up Tuple) Matrix {
+	forward := to.Sub(from).Norm()
+	left := forward.Cross(up.Norm())
+	trueUp := left.Cross(forward)
+
+	orientation := Matrix4(
+		left.X, left.Y, left.Z, 0,
+		trueUp.X, trueUp.Y, trueUp.Z, 0,
+		-forward.X, -forward.Y, -forward.Z, 0,
+		0, 0, 0, 1,
+	)
+
+	return orientation.Mul(Translation(-from.X, -from.Y, -from.Z))
+}
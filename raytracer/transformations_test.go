@@ -371,3 +371,86 @@ func TestChainTransformReversed(t *testing.T) {
 		t.Errorf("Error: %v", result)
 	}
 }
+
+func TestViewTransformDefault(t *testing.T) {
+	/* Scenario: The transformation matrix for the default orientation
+	   Given from ← point(0, 0, 0)
+	     And to ← point(0, 0, -1)
+	     And up ← vector(0, 1, 0)
+	   When t ← view_transform(from, to, up)
+	   Then t = identity_matrix */
+	from := rt.NewPoint(0, 0, 0)
+	to := rt.NewPoint(0, 0, -1)
+	up := rt.NewVector(0, 1, 0)
+
+	tr := rt.ViewTransform(from, to, up)
+
+	if !tr.Equals(rt.Identity()) {
+		t.Errorf("Error: %v", tr)
+	}
+}
+
+func TestViewTransformPositiveZ(t *testing.T) {
+	/* Scenario: A view transformation matrix looking in positive z direction
+	   Given from ← point(0, 0, 0)
+	     And to ← point(0, 0, 1)
+	     And up ← vector(0, 1, 0)
+	   When t ← view_transform(from, to, up)
+	   Then t = scaling(-1, 1, -1) */
+	from := rt.NewPoint(0, 0, 0)
+	to := rt.NewPoint(0, 0, 1)
+	up := rt.NewVector(0, 1, 0)
+
+	tr := rt.ViewTransform(from, to, up)
+
+	if !tr.Equals(rt.Scaling(-1, 1, -1)) {
+		t.Errorf("Error: %v", tr)
+	}
+}
+
+func TestViewTransformMovesWorld(t *testing.T) {
+	/* Scenario: The view transformation moves the world
+	   Given from ← point(0, 0, 8)
+	     And to ← point(0, 0, 0)
+	     And up ← vector(0, 1, 0)
+	   When t ← view_transform(from, to, up)
+	   Then t = translation(0, 0, -8) */
+	from := rt.NewPoint(0, 0, 8)
+	to := rt.NewPoint(0, 0, 0)
+	up := rt.NewVector(0, 1, 0)
+
+	tr := rt.ViewTransform(from, to, up)
+
+	if !tr.Equals(rt.Translation(0, 0, -8)) {
+		t.Errorf("Error: %v", tr)
+	}
+}
+
+func TestViewTransformArbitrary(t *testing.T) {
+	/* Scenario: An arbitrary view transformation
+	   Given from ← point(1, 3, 2)
+	     And to ← point(4, -2, 8)
+	     And up ← vector(1, 1, 0)
+	   When t ← view_transform(from, to, up)
+	   Then t is the following 4x4 matrix:
+	       | -0.50709 | 0.50709 |  0.67612 | -2.36643 |
+	       |  0.76772 | 0.60609 |  0.12122 | -2.82843 |
+	       | -0.35857 | 0.59761 | -0.71714 |  0.00000 |
+	       |  0.00000 | 0.00000 |  0.00000 |  1.00000 | */
+	from := rt.NewPoint(1, 3, 2)
+	to := rt.NewPoint(4, -2, 8)
+	up := rt.NewVector(1, 1, 0)
+
+	tr := rt.ViewTransform(from, to, up)
+
+	expected := rt.Matrix4(
+		-0.50709, 0.50709, 0.67612, -2.36643,
+		0.76772, 0.60609, 0.12122, -2.82843,
+		-0.35857, 0.59761, -0.71714, 0.00000,
+		0.00000, 0.00000, 0.00000, 1.00000,
+	)
+
+	if !tr.Equals(expected) {
+		t.Errorf("Error: %v", tr)
+	}
+}
@@ -0,0 +1,117 @@
+package raytracer
+
+import "math"
+
+type Triangle struct {
+	shape
+	P1, P2, P3 Tuple
+	E1, E2     Tuple
+	Normal     Tuple
+
+	bounds *BoundingBox
+}
+
+func NewTriangle(p1, p2, p3 Tuple) *Triangle {
+	t := &Triangle{P1: p1, P2: p2, P3: p3}
+	t.shape = newShape(t)
+
+	t.E1 = p2.Sub(p1)
+	t.E2 = p3.Sub(p1)
+	t.Normal = t.E2.Cross(t.E1).Norm()
+	t.bounds = triangleBounds(p1, p2, p3)
+
+	return t
+}
+
+func triangleBounds(p1, p2, p3 Tuple) *BoundingBox {
+	box := NewEmptyBoundingBox()
+	box.AddPoint(p1)
+	box.AddPoint(p2)
+	box.AddPoint(p3)
+	return box
+}
+
+// intersectTriangle implements the Möller–Trumbore algorithm, returning the
+// distance along r and the barycentric u/v of the hit.
+func intersectTriangle(r Ray, p1, e1, e2 Tuple) (float64, float64, float64, bool) {
+	dirCrossE2 := r.Direction.Cross(e2)
+	det := e1.Dot(dirCrossE2)
+	if math.Abs(det) < epsilon {
+		return 0, 0, 0, false
+	}
+
+	f := 1.0 / det
+	p1ToOrigin := r.Origin.Sub(p1)
+	u := f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+
+	originCrossE1 := p1ToOrigin.Cross(e1)
+	v := f * r.Direction.Dot(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+
+	return f * e2.Dot(originCrossE1), u, v, true
+}
+
+func (t *Triangle) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	tt, _, _, ok := intersectTriangle(r, t.P1, t.E1, t.E2)
+	if !ok {
+		return xs
+	}
+
+	return append(xs, NewIntersection(tt, t))
+}
+
+func (t *Triangle) LocalNormalAt(p Tuple) Tuple {
+	return t.Normal
+}
+
+func (t *Triangle) Bounds() *BoundingBox {
+	return t.bounds
+}
+
+type SmoothTriangle struct {
+	shape
+	P1, P2, P3 Tuple
+	N1, N2, N3 Tuple
+	E1, E2     Tuple
+
+	bounds *BoundingBox
+}
+
+func NewSmoothTriangle(p1, p2, p3, n1, n2, n3 Tuple) *SmoothTriangle {
+	t := &SmoothTriangle{P1: p1, P2: p2, P3: p3, N1: n1, N2: n2, N3: n3}
+	t.shape = newShape(t)
+
+	t.E1 = p2.Sub(p1)
+	t.E2 = p3.Sub(p1)
+	t.bounds = triangleBounds(p1, p2, p3)
+
+	return t
+}
+
+func (t *SmoothTriangle) AppendLocalIntersections(xs Intersections, r Ray) Intersections {
+	tt, u, v, ok := intersectTriangle(r, t.P1, t.E1, t.E2)
+	if !ok {
+		return xs
+	}
+
+	return append(xs, NewIntersectionWithUV(tt, t, u, v))
+}
+
+func (t *SmoothTriangle) LocalNormalAt(p Tuple) Tuple {
+	return t.E2.Cross(t.E1).Norm()
+}
+
+func (t *SmoothTriangle) LocalNormalAtHit(p Tuple, hit *Intersection) Tuple {
+	return t.N2.Mul(hit.U).
+		Add(t.N3.Mul(hit.V)).
+		Add(t.N1.Mul(1 - hit.U - hit.V))
+}
+
+func (t *SmoothTriangle) Bounds() *BoundingBox {
+	return t.bounds
+}
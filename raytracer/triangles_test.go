@@ -0,0 +1,170 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestNewTriangle(t *testing.T) {
+	/* Scenario: Constructing a triangle
+	   Given p1 ← point(0, 1, 0)
+	     And p2 ← point(-1, 0, 0)
+	     And p3 ← point(1, 0, 0)
+	     And t ← triangle(p1, p2, p3)
+	   Then t.p1 = p1
+	     And t.p2 = p2
+	     And t.p3 = p3
+	     And t.e1 = vector(-1, -1, 0)
+	     And t.e2 = vector(1, -1, 0)
+	     And t.normal = vector(0, 0, -1) */
+	p1 := rt.NewPoint(0, 1, 0)
+	p2 := rt.NewPoint(-1, 0, 0)
+	p3 := rt.NewPoint(1, 0, 0)
+	tr := rt.NewTriangle(p1, p2, p3)
+
+	if tr.P1 != p1 || tr.P2 != p2 || tr.P3 != p3 {
+		t.Errorf("Error: %v", tr)
+	}
+
+	if !tr.E1.Equals(rt.NewVector(-1, -1, 0)) {
+		t.Errorf("Error: %v", tr.E1)
+	}
+
+	if !tr.E2.Equals(rt.NewVector(1, -1, 0)) {
+		t.Errorf("Error: %v", tr.E2)
+	}
+
+	if !tr.Normal.Equals(rt.NewVector(0, 0, -1)) {
+		t.Errorf("Error: %v", tr.Normal)
+	}
+}
+
+func TestTriangleNormal(t *testing.T) {
+	/* Scenario: Finding the normal on a triangle
+	   Given t ← triangle(point(0, 1, 0), point(-1, 0, 0), point(1, 0, 0))
+	   When n1 ← local_normal_at(t, point(0, 0.5, 0))
+	     And n2 ← local_normal_at(t, point(-0.5, 0.75, 0))
+	     And n3 ← local_normal_at(t, point(0.5, 0.25, 0))
+	   Then n1 = t.normal
+	     And n2 = t.normal
+	     And n3 = t.normal */
+	tr := rt.NewTriangle(rt.NewPoint(0, 1, 0), rt.NewPoint(-1, 0, 0), rt.NewPoint(1, 0, 0))
+
+	n1 := tr.LocalNormalAt(rt.NewPoint(0, 0.5, 0))
+	n2 := tr.LocalNormalAt(rt.NewPoint(-0.5, 0.75, 0))
+	n3 := tr.LocalNormalAt(rt.NewPoint(0.5, 0.25, 0))
+
+	for _, n := range []rt.Tuple{n1, n2, n3} {
+		if !n.Equals(tr.Normal) {
+			t.Errorf("Error: %v", n)
+		}
+	}
+}
+
+func TestTriangleMiss(t *testing.T) {
+	/* Scenario Outline: A ray misses a triangle
+	   Given t ← triangle(point(0, 1, 0), point(-1, 0, 0), point(1, 0, 0))
+	     And r ← ray(<origin>, <direction>)
+	   When xs ← local_intersect(t, r)
+	   Then xs is empty */
+	examples := []struct {
+		origin, direction rt.Tuple
+	}{
+		{rt.NewPoint(0, -1, -2), rt.NewVector(0, 1, 0)},
+		{rt.NewPoint(1, 1, -2), rt.NewVector(0, 0, 1)},
+		{rt.NewPoint(-1, 1, -2), rt.NewVector(0, 0, 1)},
+		{rt.NewPoint(0, -1, -2), rt.NewVector(0, 0, 1)},
+	}
+
+	tr := rt.NewTriangle(rt.NewPoint(0, 1, 0), rt.NewPoint(-1, 0, 0), rt.NewPoint(1, 0, 0))
+	for _, example := range examples {
+		xs := tr.LocalIntersect(rt.NewRay(example.origin, example.direction))
+
+		if len(xs) != 0 {
+			t.Errorf("Error: %v", len(xs))
+		}
+	}
+}
+
+func TestTriangleHit(t *testing.T) {
+	/* Scenario: A ray strikes a triangle
+	   Given t ← triangle(point(0, 1, 0), point(-1, 0, 0), point(1, 0, 0))
+	     And r ← ray(point(0, 0.5, -2), vector(0, 0, 1))
+	   When xs ← local_intersect(t, r)
+	   Then xs.count = 1
+	     And xs[0].t = 2 */
+	tr := rt.NewTriangle(rt.NewPoint(0, 1, 0), rt.NewPoint(-1, 0, 0), rt.NewPoint(1, 0, 0))
+	r := rt.NewRay(rt.NewPoint(0, 0.5, -2), rt.NewVector(0, 0, 1))
+
+	xs := tr.LocalIntersect(r)
+
+	if len(xs) != 1 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	if xs[0].T != 2 {
+		t.Errorf("Error: %v", xs[0].T)
+	}
+}
+
+func newTestSmoothTriangle() *rt.SmoothTriangle {
+	return rt.NewSmoothTriangle(
+		rt.NewPoint(0, 1, 0), rt.NewPoint(-1, 0, 0), rt.NewPoint(1, 0, 0),
+		rt.NewVector(0, 1, 0), rt.NewVector(-1, 0, 0), rt.NewVector(1, 0, 0),
+	)
+}
+
+func TestSmoothTriangleUV(t *testing.T) {
+	/* Scenario: An intersection with a smooth triangle stores u/v
+	   When r ← ray(point(-0.2, 0.3, -2), vector(0, 0, 1))
+	     And xs ← local_intersect(tri, r)
+	   Then xs[0].u = 0.45
+	     And xs[0].v = 0.25 */
+	tri := newTestSmoothTriangle()
+	r := rt.NewRay(rt.NewPoint(-0.2, 0.3, -2), rt.NewVector(0, 0, 1))
+
+	xs := tri.LocalIntersect(r)
+
+	if len(xs) != 1 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	if math.Abs(xs[0].U-0.45) > 0.00001 || math.Abs(xs[0].V-0.25) > 0.00001 {
+		t.Errorf("Error: %v %v", xs[0].U, xs[0].V)
+	}
+}
+
+func TestSmoothTriangleNormal(t *testing.T) {
+	/* Scenario: A smooth triangle uses u/v to interpolate the normal
+	   When i ← intersection_with_uv(1, tri, 0.45, 0.25)
+	     And n ← normal_at(tri, point(0, 0, 0), i)
+	   Then n = vector(-0.5547, 0.83205, 0) */
+	tri := newTestSmoothTriangle()
+	i := rt.NewIntersectionWithUV(1, tri, 0.45, 0.25)
+
+	n := tri.NormalAtHit(rt.NewPoint(0, 0, 0), &i)
+
+	if !n.Equals(rt.NewVector(-0.5547, 0.83205, 0)) {
+		t.Errorf("Error: %v", n)
+	}
+}
+
+func TestSmoothTrianglePrepareComputations(t *testing.T) {
+	/* Scenario: Preparing the normal on a smooth triangle
+	   When i ← intersection_with_uv(1, tri, 0.45, 0.25)
+	     And r ← ray(point(-0.2, 0.3, -2), vector(0, 0, 1))
+	     And xs ← intersections(i)
+	     And comps ← prepare_computations(i, r, xs)
+	   Then comps.normalv = vector(-0.5547, 0.83205, 0) */
+	tri := newTestSmoothTriangle()
+	i := rt.NewIntersectionWithUV(1, tri, 0.45, 0.25)
+	r := rt.NewRay(rt.NewPoint(-0.2, 0.3, -2), rt.NewVector(0, 0, 1))
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+
+	if !comps.NormalV.Equals(rt.NewVector(-0.5547, 0.83205, 0)) {
+		t.Errorf("Error: %v", comps.NormalV)
+	}
+}
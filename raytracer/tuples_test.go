@@ -16,7 +16,7 @@ func TestPointTuple(t *testing.T) {
 	     And a.w = 1.0
 	     And a is a point
 		 And a is not a vector */
-	a := &rt.Tuple{4.3, -4.2, 3.1, 1.0}
+	a := rt.Tuple{4.3, -4.2, 3.1, 1.0}
 
 	if a.X != 4.3 || a.Y != -4.2 || a.Z != 3.1 || a.W != 1.0 {
 		t.Errorf("Error: %v", a)
@@ -36,7 +36,7 @@ func TestVectorTuple(t *testing.T) {
 	     And a.w = 0.0
 	     And a is not a point
 		 And a is a vector */
-	a := &rt.Tuple{4.3, -4.2, 3.1, 0.0}
+	a := rt.Tuple{4.3, -4.2, 3.1, 0.0}
 
 	if a.X != 4.3 || a.Y != -4.2 || a.Z != 3.1 || a.W != 0.0 {
 		t.Errorf("Error: %v", a)
@@ -53,7 +53,7 @@ func TestCreatePointTuple(t *testing.T) {
 	   Then p = tuple(4, -4, 3, 1) */
 	p := rt.NewPoint(4, -4, 3)
 
-	expected := &rt.Tuple{4, -4, 3, 1}
+	expected := rt.Tuple{4, -4, 3, 1}
 	if !p.Equals(expected) {
 		t.Errorf("Error: %v", p)
 	}
@@ -65,7 +65,7 @@ func TestCreateVectorTuple(t *testing.T) {
 	   Then v = tuple(4, -4, 3, 0) */
 	v := rt.NewVector(4, -4, 3)
 
-	expected := &rt.Tuple{4, -4, 3, 0}
+	expected := rt.Tuple{4, -4, 3, 0}
 	if !v.Equals(expected) {
 		t.Errorf("Error: %v", v)
 	}
@@ -76,12 +76,12 @@ func TestAdd2Tuples(t *testing.T) {
 	   Given a1 ← tuple(3, -2, 5, 1)
 	     And a2 ← tuple(-2, 3, 1, 0)
 		Then a1 + a2 = tuple(1, 1, 6, 1) */
-	a1 := &rt.Tuple{3, -2, 5, 1}
-	a2 := &rt.Tuple{-2, 3, 1, 0}
+	a1 := rt.Tuple{3, -2, 5, 1}
+	a2 := rt.Tuple{-2, 3, 1, 0}
 
 	result := a1.Add(a2)
 
-	expected := &rt.Tuple{1, 1, 6, 1}
+	expected := rt.Tuple{1, 1, 6, 1}
 	if !result.Equals(expected) {
 		t.Errorf("Error: %v", result)
 	}
@@ -155,11 +155,11 @@ func TestNegateTuple(t *testing.T) {
 	/* Scenario: Negating a tuple
 	   Given a ← tuple(1, -2, 3, -4)
 	   Then -a = tuple(-1, 2, -3, 4) */
-	a := &rt.Tuple{1, -2, 3, -4}
+	a := rt.Tuple{1, -2, 3, -4}
 
 	result := a.Neg()
 
-	expected := &rt.Tuple{-1, 2, -3, 4}
+	expected := rt.Tuple{-1, 2, -3, 4}
 	if !result.Equals(expected) {
 		t.Errorf("Error: %v", result)
 	}
@@ -169,11 +169,11 @@ func TestMulTupleByScalar(t *testing.T) {
 	/* Scenario: Multiplying a tuple by a scalar
 	   Given a ← tuple(1, -2, 3, -4)
 	   Then a * 3.5 = tuple(3.5, -7, 10.5, -14) */
-	a := &rt.Tuple{1, -2, 3, -4}
+	a := rt.Tuple{1, -2, 3, -4}
 
 	result := a.Mul(3.5)
 
-	expected := &rt.Tuple{3.5, -7, 10.5, -14}
+	expected := rt.Tuple{3.5, -7, 10.5, -14}
 	if !result.Equals(expected) {
 		t.Errorf("Error: %v", result)
 	}
@@ -183,11 +183,11 @@ func TestMulTupleByFraction(t *testing.T) {
 	/* Scenario: Multiplying a tuple by a fraction
 	   Given a ← tuple(1, -2, 3, -4)
 	   Then a * 0.5 = tuple(0.5, -1, 1.5, -2) */
-	a := &rt.Tuple{1, -2, 3, -4}
+	a := rt.Tuple{1, -2, 3, -4}
 
 	result := a.Mul(0.5)
 
-	expected := &rt.Tuple{0.5, -1, 1.5, -2}
+	expected := rt.Tuple{0.5, -1, 1.5, -2}
 	if !result.Equals(expected) {
 		t.Errorf("Error: %v", result)
 	}
@@ -197,11 +197,11 @@ func TestDivTupleByScalar(t *testing.T) {
 	/* Scenario: Dividing a tuple by a scalar
 	   Given a ← tuple(1, -2, 3, -4)
 	   Then a / 2 = tuple(0.5, -1, 1.5, -2) */
-	a := &rt.Tuple{1, -2, 3, -4}
+	a := rt.Tuple{1, -2, 3, -4}
 
 	result := a.Div(2)
 
-	expected := &rt.Tuple{0.5, -1, 1.5, -2}
+	expected := rt.Tuple{0.5, -1, 1.5, -2}
 	if !result.Equals(expected) {
 		t.Errorf("Error: %v", result)
 	}
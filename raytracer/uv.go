@@ -0,0 +1,179 @@
+package raytracer
+
+import "math"
+
+type UVPattern interface {
+	UVPatternAt(u, v float64) *Color
+}
+
+// UVMapping maps a point on the surface of a shape, in pattern space, to
+// texture coordinates u and v in [0, 1).
+type UVMapping func(p Tuple) (float64, float64)
+
+// mod1 returns the fractional part of x, wrapped into [0, 1) for negatives.
+func mod1(x float64) float64 {
+	return x - math.Floor(x)
+}
+
+func SphericalMap(p Tuple) (float64, float64) {
+	theta := math.Atan2(p.X, p.Z)
+	radius := NewVector(p.X, p.Y, p.Z).Mag()
+	phi := math.Acos(p.Y / radius)
+
+	rawU := theta / (2 * math.Pi)
+	u := 1 - (rawU + 0.5)
+	v := 1 - phi/math.Pi
+
+	return u, v
+}
+
+func PlanarMap(p Tuple) (float64, float64) {
+	return mod1(p.X), mod1(p.Z)
+}
+
+func CylindricalMap(p Tuple) (float64, float64) {
+	theta := math.Atan2(p.X, p.Z)
+	rawU := theta / (2 * math.Pi)
+	u := 1 - (rawU + 0.5)
+
+	return u, mod1(p.Y)
+}
+
+type UVCheckers struct {
+	Width, Height float64
+	A, B          *Color
+}
+
+func NewUVCheckers(width, height float64, a, b *Color) *UVCheckers {
+	return &UVCheckers{width, height, a, b}
+}
+
+func (c *UVCheckers) UVPatternAt(u, v float64) *Color {
+	u2 := math.Floor(u * c.Width)
+	v2 := math.Floor(v * c.Height)
+
+	if int(u2+v2)%2 == 0 {
+		return c.A
+	}
+	return c.B
+}
+
+// UVAlignCheck paints a face in Main with a differently colored square in
+// each corner, which makes the orientation of a mapping easy to verify.
+type UVAlignCheck struct {
+	Main, UL, UR, BL, BR *Color
+}
+
+func NewUVAlignCheck(main, ul, ur, bl, br *Color) *UVAlignCheck {
+	return &UVAlignCheck{main, ul, ur, bl, br}
+}
+
+func (c *UVAlignCheck) UVPatternAt(u, v float64) *Color {
+	if v > 0.8 {
+		if u < 0.2 {
+			return c.UL
+		}
+		if u > 0.8 {
+			return c.UR
+		}
+	} else if v < 0.2 {
+		if u < 0.2 {
+			return c.BL
+		}
+		if u > 0.8 {
+			return c.BR
+		}
+	}
+
+	return c.Main
+}
+
+type TextureMapPattern struct {
+	pattern
+	UVPattern UVPattern
+	Mapping   UVMapping
+}
+
+func NewTextureMapPattern(uvPattern UVPattern, mapping UVMapping) *TextureMapPattern {
+	p := &TextureMapPattern{UVPattern: uvPattern, Mapping: mapping}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *TextureMapPattern) PatternAt(point Tuple) *Color {
+	u, v := p.Mapping(point)
+	return p.UVPattern.UVPatternAt(u, v)
+}
+
+type CubeFace int
+
+const (
+	CubeFaceLeft CubeFace = iota
+	CubeFaceRight
+	CubeFaceFront
+	CubeFaceBack
+	CubeFaceUp
+	CubeFaceDown
+)
+
+func FaceFromPoint(p Tuple) CubeFace {
+	absX, absY, absZ := math.Abs(p.X), math.Abs(p.Y), math.Abs(p.Z)
+	coord := math.Max(absX, math.Max(absY, absZ))
+
+	switch coord {
+	case p.X:
+		return CubeFaceRight
+	case -p.X:
+		return CubeFaceLeft
+	case p.Y:
+		return CubeFaceUp
+	case -p.Y:
+		return CubeFaceDown
+	case p.Z:
+		return CubeFaceFront
+	}
+	return CubeFaceBack
+}
+
+// CubeUV maps a point on the given face of a unit cube to u and v, as seen
+// from outside the cube looking at that face.
+func CubeUV(face CubeFace, p Tuple) (float64, float64) {
+	var u, v float64
+
+	switch face {
+	case CubeFaceFront:
+		u, v = math.Mod(p.X+1, 2)/2, math.Mod(p.Y+1, 2)/2
+	case CubeFaceBack:
+		u, v = math.Mod(1-p.X, 2)/2, math.Mod(p.Y+1, 2)/2
+	case CubeFaceLeft:
+		u, v = math.Mod(p.Z+1, 2)/2, math.Mod(p.Y+1, 2)/2
+	case CubeFaceRight:
+		u, v = math.Mod(1-p.Z, 2)/2, math.Mod(p.Y+1, 2)/2
+	case CubeFaceUp:
+		u, v = math.Mod(p.X+1, 2)/2, math.Mod(1-p.Z, 2)/2
+	case CubeFaceDown:
+		u, v = math.Mod(p.X+1, 2)/2, math.Mod(p.Z+1, 2)/2
+	}
+
+	return u, v
+}
+
+type CubeMapPattern struct {
+	pattern
+	Faces [6]UVPattern
+}
+
+// NewCubeMapPattern builds a cube map from one UV pattern per face, given in
+// the order of the CubeFace constants.
+func NewCubeMapPattern(left, right, front, back, up, down UVPattern) *CubeMapPattern {
+	p := &CubeMapPattern{Faces: [6]UVPattern{left, right, front, back, up, down}}
+	p.pattern = newPattern(p)
+	return p
+}
+
+func (p *CubeMapPattern) PatternAt(point Tuple) *Color {
+	face := FaceFromPoint(point)
+	u, v := CubeUV(face, point)
+
+	return p.Faces[face].UVPatternAt(u, v)
+}
@@ -0,0 +1,311 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestUVCheckers(t *testing.T) {
+	/* Scenario Outline: Checker pattern in 2D
+	   Given checkers ← uv_checkers(2, 2, black, white)
+	   When color ← uv_pattern_at(checkers, <u>, <v>)
+	   Then color = <expected> */
+	checkers := rt.NewUVCheckers(2, 2, black, white)
+
+	examples := []struct {
+		u, v     float64
+		expected *rt.Color
+	}{
+		{0.0, 0.0, black},
+		{0.5, 0.0, white},
+		{0.0, 0.5, white},
+		{0.5, 0.5, black},
+		{1.0, 1.0, black},
+	}
+
+	for _, example := range examples {
+		if !checkers.UVPatternAt(example.u, example.v).Equals(example.expected) {
+			t.Errorf("Error: %v %v", example.u, example.v)
+		}
+	}
+}
+
+func TestSphericalMap(t *testing.T) {
+	/* Scenario Outline: Using a spherical mapping on a 3D point
+	   Given p ← <point>
+	   When (u, v) ← spherical_map(p)
+	   Then u = <u>
+	     And v = <v> */
+	examples := []struct {
+		point rt.Tuple
+		u, v  float64
+	}{
+		{rt.NewPoint(0, 0, -1), 0.0, 0.5},
+		{rt.NewPoint(1, 0, 0), 0.25, 0.5},
+		{rt.NewPoint(0, 0, 1), 0.5, 0.5},
+		{rt.NewPoint(-1, 0, 0), 0.75, 0.5},
+		{rt.NewPoint(0, 1, 0), 0.5, 1.0},
+		{rt.NewPoint(0, -1, 0), 0.5, 0.0},
+		{rt.NewPoint(math.Sqrt(2)/2, math.Sqrt(2)/2, 0), 0.25, 0.75},
+	}
+
+	for _, example := range examples {
+		u, v := rt.SphericalMap(example.point)
+
+		if math.Abs(u-example.u) > 0.00001 || math.Abs(v-example.v) > 0.00001 {
+			t.Errorf("Error: %v %v %v", example.point, u, v)
+		}
+	}
+}
+
+func TestTextureMapSpherical(t *testing.T) {
+	/* Scenario Outline: Using a texture map pattern with a spherical map
+	   Given checkers ← uv_checkers(16, 8, black, white)
+	     And pattern ← texture_map(checkers, spherical_map)
+	   Then pattern_at(pattern, <point>) = <color> */
+	checkers := rt.NewUVCheckers(16, 8, black, white)
+	pattern := rt.NewTextureMapPattern(checkers, rt.SphericalMap)
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(0.4315, 0.4670, 0.7719), white},
+		{rt.NewPoint(-0.9654, 0.2552, -0.0534), black},
+		{rt.NewPoint(0.1039, 0.7090, 0.6975), white},
+		{rt.NewPoint(-0.4986, -0.7856, -0.3663), black},
+		{rt.NewPoint(-0.0317, -0.9395, 0.3411), black},
+		{rt.NewPoint(0.4809, -0.7721, 0.4154), black},
+		{rt.NewPoint(0.0285, -0.9612, -0.2745), black},
+		{rt.NewPoint(-0.5734, -0.2162, -0.7903), white},
+		{rt.NewPoint(0.7688, -0.1470, 0.6223), black},
+		{rt.NewPoint(-0.7652, 0.2175, 0.6060), black},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
+
+func TestPlanarMap(t *testing.T) {
+	/* Scenario Outline: Using a planar mapping on a 3D point
+	   Given p ← <point>
+	   When (u, v) ← planar_map(p)
+	   Then u = <u>
+	     And v = <v> */
+	examples := []struct {
+		point rt.Tuple
+		u, v  float64
+	}{
+		{rt.NewPoint(0.25, 0, 0.5), 0.25, 0.5},
+		{rt.NewPoint(0.25, 0, -0.25), 0.25, 0.75},
+		{rt.NewPoint(0.25, 0.5, -0.25), 0.25, 0.75},
+		{rt.NewPoint(1.25, 0, 0.5), 0.25, 0.5},
+		{rt.NewPoint(0.25, 0, -1.75), 0.25, 0.25},
+		{rt.NewPoint(1, 0, -1), 0.0, 0.0},
+		{rt.NewPoint(0, 0, 0), 0.0, 0.0},
+	}
+
+	for _, example := range examples {
+		u, v := rt.PlanarMap(example.point)
+
+		if math.Abs(u-example.u) > 0.00001 || math.Abs(v-example.v) > 0.00001 {
+			t.Errorf("Error: %v %v %v", example.point, u, v)
+		}
+	}
+}
+
+func TestCylindricalMap(t *testing.T) {
+	/* Scenario Outline: Using a cylindrical mapping on a 3D point
+	   Given p ← <point>
+	   When (u, v) ← cylindrical_map(p)
+	   Then u = <u>
+	     And v = <v> */
+	examples := []struct {
+		point rt.Tuple
+		u, v  float64
+	}{
+		{rt.NewPoint(0, 0, -1), 0.0, 0.0},
+		{rt.NewPoint(0, 0.5, -1), 0.0, 0.5},
+		{rt.NewPoint(0, 1, -1), 0.0, 0.0},
+		{rt.NewPoint(0.70711, 0.5, -0.70711), 0.125, 0.5},
+		{rt.NewPoint(1, 0.5, 0), 0.25, 0.5},
+		{rt.NewPoint(0.70711, 0.5, 0.70711), 0.375, 0.5},
+		{rt.NewPoint(0, -0.25, 1), 0.5, 0.75},
+		{rt.NewPoint(-0.70711, 0.5, 0.70711), 0.625, 0.5},
+		{rt.NewPoint(-1, 1.25, 0), 0.75, 0.25},
+		{rt.NewPoint(-0.70711, 0.5, -0.70711), 0.875, 0.5},
+	}
+
+	for _, example := range examples {
+		u, v := rt.CylindricalMap(example.point)
+
+		if math.Abs(u-example.u) > 0.00001 || math.Abs(v-example.v) > 0.00001 {
+			t.Errorf("Error: %v %v %v", example.point, u, v)
+		}
+	}
+}
+
+func TestUVAlignCheck(t *testing.T) {
+	/* Scenario Outline: Layout of the "align check" pattern
+	   Given main ← color(1, 1, 1)
+	     And ul ← color(1, 0, 0)
+	     And ur ← color(1, 1, 0)
+	     And bl ← color(0, 1, 0)
+	     And br ← color(0, 1, 1)
+	     And pattern ← uv_align_check(main, ul, ur, bl, br)
+	   When c ← uv_pattern_at(pattern, <u>, <v>)
+	   Then c = <expected> */
+	main := &rt.Color{1, 1, 1}
+	ul := &rt.Color{1, 0, 0}
+	ur := &rt.Color{1, 1, 0}
+	bl := &rt.Color{0, 1, 0}
+	br := &rt.Color{0, 1, 1}
+	pattern := rt.NewUVAlignCheck(main, ul, ur, bl, br)
+
+	examples := []struct {
+		u, v     float64
+		expected *rt.Color
+	}{
+		{0.5, 0.5, main},
+		{0.1, 0.9, ul},
+		{0.9, 0.9, ur},
+		{0.1, 0.1, bl},
+		{0.9, 0.1, br},
+	}
+
+	for _, example := range examples {
+		if pattern.UVPatternAt(example.u, example.v) != example.expected {
+			t.Errorf("Error: %v %v", example.u, example.v)
+		}
+	}
+}
+
+func TestFaceFromPoint(t *testing.T) {
+	/* Scenario Outline: Identifying the face of a cube from a point
+	   When face ← face_from_point(<point>)
+	   Then face = <face> */
+	examples := []struct {
+		point rt.Tuple
+		face  rt.CubeFace
+	}{
+		{rt.NewPoint(-1, 0.5, -0.25), rt.CubeFaceLeft},
+		{rt.NewPoint(1.1, -0.75, 0.8), rt.CubeFaceRight},
+		{rt.NewPoint(0.1, 0.6, 0.9), rt.CubeFaceFront},
+		{rt.NewPoint(-0.7, 0, -2), rt.CubeFaceBack},
+		{rt.NewPoint(0.5, 1, 0.9), rt.CubeFaceUp},
+		{rt.NewPoint(-0.2, -1.3, 1.1), rt.CubeFaceDown},
+	}
+
+	for _, example := range examples {
+		if face := rt.FaceFromPoint(example.point); face != example.face {
+			t.Errorf("Error: %v %v", example.point, face)
+		}
+	}
+}
+
+func TestCubeUV(t *testing.T) {
+	/* Scenario Outline: UV mapping the faces of a cube
+	   When (u, v) ← cube_uv_<face>(<point>)
+	   Then u = <u>
+	     And v = <v> */
+	examples := []struct {
+		face  rt.CubeFace
+		point rt.Tuple
+		u, v  float64
+	}{
+		{rt.CubeFaceFront, rt.NewPoint(-0.5, 0.5, 1), 0.25, 0.75},
+		{rt.CubeFaceFront, rt.NewPoint(0.5, -0.5, 1), 0.75, 0.25},
+		{rt.CubeFaceBack, rt.NewPoint(0.5, 0.5, -1), 0.25, 0.75},
+		{rt.CubeFaceBack, rt.NewPoint(-0.5, -0.5, -1), 0.75, 0.25},
+		{rt.CubeFaceLeft, rt.NewPoint(-1, 0.5, -0.5), 0.25, 0.75},
+		{rt.CubeFaceLeft, rt.NewPoint(-1, -0.5, 0.5), 0.75, 0.25},
+		{rt.CubeFaceRight, rt.NewPoint(1, 0.5, 0.5), 0.25, 0.75},
+		{rt.CubeFaceRight, rt.NewPoint(1, -0.5, -0.5), 0.75, 0.25},
+		{rt.CubeFaceUp, rt.NewPoint(-0.5, 1, -0.5), 0.25, 0.75},
+		{rt.CubeFaceUp, rt.NewPoint(0.5, 1, 0.5), 0.75, 0.25},
+		{rt.CubeFaceDown, rt.NewPoint(-0.5, -1, 0.5), 0.25, 0.75},
+		{rt.CubeFaceDown, rt.NewPoint(0.5, -1, -0.5), 0.75, 0.25},
+	}
+
+	for _, example := range examples {
+		u, v := rt.CubeUV(example.face, example.point)
+
+		if math.Abs(u-example.u) > 0.00001 || math.Abs(v-example.v) > 0.00001 {
+			t.Errorf("Error: %v %v %v", example.point, u, v)
+		}
+	}
+}
+
+func TestCubeMapPattern(t *testing.T) {
+	/* Scenario Outline: Finding the colors on a mapped cube
+	   When left ← uv_align_check(yellow, cyan, red, blue, brown)
+	     And front ← uv_align_check(cyan, red, yellow, brown, green)
+	     And right ← uv_align_check(red, yellow, purple, green, white)
+	     And back ← uv_align_check(green, purple, cyan, white, blue)
+	     And up ← uv_align_check(brown, cyan, purple, red, yellow)
+	     And down ← uv_align_check(purple, brown, green, blue, white)
+	     And pattern ← cube_map(left, front, right, back, up, down)
+	   Then pattern_at(pattern, <point>) = <color> */
+	red := &rt.Color{1, 0, 0}
+	yellow := &rt.Color{1, 1, 0}
+	brown := &rt.Color{1, 0.5, 0}
+	green := &rt.Color{0, 1, 0}
+	cyan := &rt.Color{0, 1, 1}
+	blue := &rt.Color{0, 0, 1}
+	purple := &rt.Color{1, 0, 1}
+
+	left := rt.NewUVAlignCheck(yellow, cyan, red, blue, brown)
+	front := rt.NewUVAlignCheck(cyan, red, yellow, brown, green)
+	right := rt.NewUVAlignCheck(red, yellow, purple, green, white)
+	back := rt.NewUVAlignCheck(green, purple, cyan, white, blue)
+	up := rt.NewUVAlignCheck(brown, cyan, purple, red, yellow)
+	down := rt.NewUVAlignCheck(purple, brown, green, blue, white)
+	pattern := rt.NewCubeMapPattern(left, right, front, back, up, down)
+
+	examples := []struct {
+		point    rt.Tuple
+		expected *rt.Color
+	}{
+		{rt.NewPoint(-1, 0, 0), yellow},
+		{rt.NewPoint(-1, 0.9, -0.9), cyan},
+		{rt.NewPoint(-1, 0.9, 0.9), red},
+		{rt.NewPoint(-1, -0.9, -0.9), blue},
+		{rt.NewPoint(-1, -0.9, 0.9), brown},
+		{rt.NewPoint(0, 0, 1), cyan},
+		{rt.NewPoint(-0.9, 0.9, 1), red},
+		{rt.NewPoint(0.9, 0.9, 1), yellow},
+		{rt.NewPoint(-0.9, -0.9, 1), brown},
+		{rt.NewPoint(0.9, -0.9, 1), green},
+		{rt.NewPoint(1, 0, 0), red},
+		{rt.NewPoint(1, 0.9, 0.9), yellow},
+		{rt.NewPoint(1, 0.9, -0.9), purple},
+		{rt.NewPoint(1, -0.9, 0.9), green},
+		{rt.NewPoint(1, -0.9, -0.9), white},
+		{rt.NewPoint(0, 0, -1), green},
+		{rt.NewPoint(0.9, 0.9, -1), purple},
+		{rt.NewPoint(-0.9, 0.9, -1), cyan},
+		{rt.NewPoint(0.9, -0.9, -1), white},
+		{rt.NewPoint(-0.9, -0.9, -1), blue},
+		{rt.NewPoint(0, 1, 0), brown},
+		{rt.NewPoint(-0.9, 1, -0.9), cyan},
+		{rt.NewPoint(0.9, 1, -0.9), purple},
+		{rt.NewPoint(-0.9, 1, 0.9), red},
+		{rt.NewPoint(0.9, 1, 0.9), yellow},
+		{rt.NewPoint(0, -1, 0), purple},
+		{rt.NewPoint(-0.9, -1, 0.9), brown},
+		{rt.NewPoint(0.9, -1, 0.9), green},
+		{rt.NewPoint(-0.9, -1, -0.9), blue},
+		{rt.NewPoint(0.9, -1, -0.9), white},
+	}
+
+	for _, example := range examples {
+		if !pattern.PatternAt(example.point).Equals(example.expected) {
+			t.Errorf("Error: %v", example.point)
+		}
+	}
+}
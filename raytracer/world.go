@@ -0,0 +1,180 @@
+package raytracer
+
+import "math"
+
+const DefaultMaxDepth = 5
+
+type World struct {
+	Objects  []Shape
+	Lights   []*PointLight
+	MaxDepth int
+}
+
+func NewWorld() *World {
+	return &World{MaxDepth: DefaultMaxDepth}
+}
+
+func DefaultWorld() *World {
+	w := NewWorld()
+
+	w.AddLight(NewPointLight(NewPoint(-10, 10, -10), &Color{1, 1, 1}))
+
+	s1 := NewSphere()
+	s1.Material.Color = &Color{0.8, 1.0, 0.6}
+	s1.Material.Diffuse = 0.7
+	s1.Material.Specular = 0.2
+
+	s2 := NewSphere()
+	s2.SetTransform(Scaling(0.5, 0.5, 0.5))
+
+	w.AddObject(s1, s2)
+
+	return w
+}
+
+func (w *World) AddObject(objects ...Shape) {
+	w.Objects = append(w.Objects, objects...)
+}
+
+func (w *World) AddLight(lights ...*PointLight) {
+	w.Lights = append(w.Lights, lights...)
+}
+
+func (w *World) IntersectWorld(r Ray) Intersections {
+	return w.AppendIntersections(NewIntersections(), r)
+}
+
+// AppendIntersections appends the intersections of r with every object in
+// the world to xs, sorting the ones it added.
+func (w *World) AppendIntersections(xs Intersections, r Ray) Intersections {
+	start := len(xs)
+	for _, object := range w.Objects {
+		xs = object.AppendIntersections(xs, r)
+	}
+	xs[start:].Sort()
+
+	return xs
+}
+
+func (w *World) ShadeHit(comps *Computations, remaining int) *Color {
+	return NewTracer(w).shadeHit(comps, remaining)
+}
+
+func (w *World) ColorAt(r Ray) *Color {
+	return NewTracer(w).ColorAt(r)
+}
+
+func (w *World) ReflectedColor(comps *Computations, remaining int) *Color {
+	return NewTracer(w).reflectedColor(comps, remaining)
+}
+
+func (w *World) IsShadowed(p Tuple, l *PointLight) bool {
+	return NewTracer(w).isShadowed(p, l)
+}
+
+func (w *World) RefractedColor(comps *Computations, remaining int) *Color {
+	return NewTracer(w).refractedColor(comps, remaining)
+}
+
+// Tracer traces rays through a world, reusing one list of intersections for
+// every ray. The list is only needed until the computations of the hit are
+// prepared, so shadow, reflected and refracted rays can all share it. A
+// Tracer must not be used by more than one goroutine at a time, every render
+// worker has its own.
+type Tracer struct {
+	world *World
+	xs    Intersections
+}
+
+func NewTracer(w *World) *Tracer {
+	return &Tracer{world: w}
+}
+
+// IntersectWorld returns the sorted intersections of r with the world. They
+// are only valid until the next ray is traced.
+func (t *Tracer) IntersectWorld(r Ray) Intersections {
+	t.xs = t.world.AppendIntersections(t.xs[:0], r)
+	return t.xs
+}
+
+func (t *Tracer) ColorAt(r Ray) *Color {
+	return t.colorAt(r, t.world.MaxDepth)
+}
+
+func (t *Tracer) colorAt(r Ray, remaining int) *Color {
+	xs := t.IntersectWorld(r)
+	hit := xs.Hit()
+	if hit == nil {
+		return &Color{0, 0, 0}
+	}
+
+	comps := hit.PrepareComputations(r, xs)
+
+	return t.shadeHit(comps, remaining)
+}
+
+func (t *Tracer) shadeHit(comps *Computations, remaining int) *Color {
+	color := &Color{0, 0, 0}
+
+	for _, light := range t.world.Lights {
+		inShadow := t.isShadowed(comps.OverPoint, light)
+		color = color.Add(comps.Object.GetMaterial().Lighting(comps.Object, light, comps.OverPoint, comps.EyeV, comps.NormalV, inShadow))
+	}
+
+	reflected := t.reflectedColor(comps, remaining)
+	refracted := t.refractedColor(comps, remaining)
+
+	material := comps.Object.GetMaterial()
+	if material.Reflective > 0 && material.Transparency > 0 {
+		reflectance := comps.Schlick()
+		return color.Add(reflected.Mul(reflectance)).Add(refracted.Mul(1 - reflectance))
+	}
+
+	return color.Add(reflected).Add(refracted)
+}
+
+func (t *Tracer) reflectedColor(comps *Computations, remaining int) *Color {
+	reflective := comps.Object.GetMaterial().Reflective
+	if remaining <= 0 || reflective == 0 {
+		return &Color{0, 0, 0}
+	}
+
+	reflectRay := NewRay(comps.OverPoint, comps.ReflectV)
+	color := t.colorAt(reflectRay, remaining-1)
+
+	return color.Mul(reflective)
+}
+
+func (t *Tracer) isShadowed(p Tuple, l *PointLight) bool {
+	v := l.Position.Sub(p)
+	distance := v.Mag()
+	direction := v.Norm()
+
+	r := NewRay(p, direction)
+	hit := t.IntersectWorld(r).Hit()
+
+	return hit != nil && hit.T < distance
+}
+
+func (t *Tracer) refractedColor(comps *Computations, remaining int) *Color {
+	transparency := comps.Object.GetMaterial().Transparency
+	if remaining <= 0 || transparency == 0 {
+		return &Color{0, 0, 0}
+	}
+
+	nRatio := comps.N1 / comps.N2
+	cosI := comps.EyeV.Dot(comps.NormalV)
+	sin2t := math.Pow(nRatio, 2) * (1 - math.Pow(cosI, 2))
+	if sin2t > 1 {
+		// total internal reflection
+		return &Color{0, 0, 0}
+	}
+
+	cosT := math.Sqrt(1 - sin2t)
+	direction := comps.NormalV.Mul(nRatio*cosI - cosT).Sub(comps.EyeV.Mul(nRatio))
+	refractRay := NewRay(comps.UnderPoint, direction)
+
+	color := t.colorAt(refractRay, remaining-1)
+
+	return color.Mul(transparency)
+}
@@ -0,0 +1,659 @@
+package raytracer_test
+
+import (
+	"math"
+	"testing"
+
+	rt "github.com/gumuz/go-raytracer/raytracer"
+)
+
+func TestNewWorld(t *testing.T) {
+	/* Scenario: Creating a world
+	   Given w ← world()
+	   Then w contains no objects
+	     And w has no light source */
+	w := rt.NewWorld()
+
+	if len(w.Objects) != 0 {
+		t.Errorf("Error: %v", w.Objects)
+	}
+
+	if len(w.Lights) != 0 {
+		t.Errorf("Error: %v", w.Lights)
+	}
+}
+
+func TestDefaultWorld(t *testing.T) {
+	/* Scenario: The default world
+	   Given light ← point_light(point(-10, 10, -10), color(1, 1, 1))
+	     And s1 ← sphere() with:
+	       | material.color     | (0.8, 1.0, 0.6)        |
+	       | material.diffuse   | 0.7                    |
+	       | material.specular  | 0.2                    |
+	     And s2 ← sphere() with:
+	       | transform | scaling(0.5, 0.5, 0.5) |
+	   When w ← default_world()
+	   Then w.light = light
+	     And w contains s1
+	     And w contains s2 */
+	w := rt.DefaultWorld()
+
+	if len(w.Lights) != 1 || !w.Lights[0].Position.Equals(rt.NewPoint(-10, 10, -10)) || !w.Lights[0].Intensity.Equals(&rt.Color{1, 1, 1}) {
+		t.Errorf("Error: %v", w.Lights)
+	}
+
+	if len(w.Objects) != 2 {
+		t.Fatalf("Error: %v", w.Objects)
+	}
+
+	s1 := w.Objects[0].(*rt.Sphere)
+	if !s1.Material.Color.Equals(&rt.Color{0.8, 1.0, 0.6}) || s1.Material.Diffuse != 0.7 || s1.Material.Specular != 0.2 {
+		t.Errorf("Error: %v", s1.Material)
+	}
+
+	s2 := w.Objects[1].(*rt.Sphere)
+	if !s2.Transform.Equals(rt.Scaling(0.5, 0.5, 0.5)) {
+		t.Errorf("Error: %v", s2.Transform)
+	}
+}
+
+func TestIntersectWorld(t *testing.T) {
+	/* Scenario: Intersect a world with a ray
+	   Given w ← default_world()
+	     And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	   When xs ← intersect_world(w, r)
+	   Then xs.count = 4
+	     And xs[0].t = 4
+	     And xs[1].t = 4.5
+	     And xs[2].t = 5.5
+	     And xs[3].t = 6 */
+	w := rt.DefaultWorld()
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+
+	xs := w.IntersectWorld(r)
+
+	if len(xs) != 4 {
+		t.Fatalf("Error: %v", len(xs))
+	}
+
+	for idx, expected := range []float64{4, 4.5, 5.5, 6} {
+		if xs[idx].T != expected {
+			t.Errorf("Error: %v", xs[idx].T)
+		}
+	}
+}
+
+func TestShadeHit(t *testing.T) {
+	/* Scenario: Shading an intersection
+	   Given w ← default_world()
+	     And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And shape ← the first object in w
+	     And i ← intersection(4, shape)
+	   When comps ← prepare_computations(i, r)
+	     And c ← shade_hit(w, comps)
+	   Then c = color(0.38066, 0.47583, 0.2855) */
+	w := rt.DefaultWorld()
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	shape := w.Objects[0]
+	i := rt.NewIntersection(4, shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+	c := w.ShadeHit(comps, rt.DefaultMaxDepth)
+
+	if !c.Equals(&rt.Color{0.38066, 0.47583, 0.2855}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestShadeHitInside(t *testing.T) {
+	/* Scenario: Shading an intersection from the inside
+	   Given w ← default_world()
+	     And w.light ← point_light(point(0, 0.25, 0), color(1, 1, 1))
+	     And r ← ray(point(0, 0, 0), vector(0, 0, 1))
+	     And shape ← the second object in w
+	     And i ← intersection(0.5, shape)
+	   When comps ← prepare_computations(i, r)
+	     And c ← shade_hit(w, comps)
+	   Then c = color(0.90498, 0.90498, 0.90498) */
+	w := rt.DefaultWorld()
+	w.Lights = []*rt.PointLight{rt.NewPointLight(rt.NewPoint(0, 0.25, 0), &rt.Color{1, 1, 1})}
+	r := rt.NewRay(rt.NewPoint(0, 0, 0), rt.NewVector(0, 0, 1))
+	shape := w.Objects[1]
+	i := rt.NewIntersection(0.5, shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+	c := w.ShadeHit(comps, rt.DefaultMaxDepth)
+
+	if !c.Equals(&rt.Color{0.90498, 0.90498, 0.90498}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestColorAtMiss(t *testing.T) {
+	/* Scenario: The color when a ray misses
+	   Given w ← default_world()
+	     And r ← ray(point(0, 0, -5), vector(0, 1, 0))
+	   When c ← color_at(w, r)
+	   Then c = color(0, 0, 0) */
+	w := rt.DefaultWorld()
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 1, 0))
+
+	c := w.ColorAt(r)
+
+	if !c.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestColorAtHit(t *testing.T) {
+	/* Scenario: The color when a ray hits
+	   Given w ← default_world()
+	     And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	   When c ← color_at(w, r)
+	   Then c = color(0.38066, 0.47583, 0.2855) */
+	w := rt.DefaultWorld()
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+
+	c := w.ColorAt(r)
+
+	if !c.Equals(&rt.Color{0.38066, 0.47583, 0.2855}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestColorAtIntersectionBehindRay(t *testing.T) {
+	/* Scenario: The color with an intersection behind the ray
+	   Given w ← default_world()
+	     And outer ← the first object in w
+	     And outer.material.ambient ← 1
+	     And inner ← the second object in w
+	     And inner.material.ambient ← 1
+	     And r ← ray(point(0, 0, 0.75), vector(0, 0, -1))
+	   When c ← color_at(w, r)
+	   Then c = inner.material.color */
+	w := rt.DefaultWorld()
+	outer := w.Objects[0]
+	outer.GetMaterial().Ambient = 1
+	inner := w.Objects[1]
+	inner.GetMaterial().Ambient = 1
+	r := rt.NewRay(rt.NewPoint(0, 0, 0.75), rt.NewVector(0, 0, -1))
+
+	c := w.ColorAt(r)
+
+	if !c.Equals(inner.GetMaterial().Color) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestIsShadowedNothingCollinear(t *testing.T) {
+	/* Scenario: There is no shadow when nothing is collinear with point and light
+	   Given w ← default_world()
+	     And p ← point(0, 10, 0)
+	    Then is_shadowed(w, p) is false */
+	w := rt.DefaultWorld()
+	p := rt.NewPoint(0, 10, 0)
+
+	if w.IsShadowed(p, w.Lights[0]) {
+		t.Errorf("Error: %v", p)
+	}
+}
+
+func TestIsShadowedObjectBetween(t *testing.T) {
+	/* Scenario: The shadow when an object is between the point and the light
+	   Given w ← default_world()
+	     And p ← point(10, -10, 10)
+	    Then is_shadowed(w, p) is true */
+	w := rt.DefaultWorld()
+	p := rt.NewPoint(10, -10, 10)
+
+	if !w.IsShadowed(p, w.Lights[0]) {
+		t.Errorf("Error: %v", p)
+	}
+}
+
+func TestIsShadowedObjectBehindLight(t *testing.T) {
+	/* Scenario: There is no shadow when an object is behind the light
+	   Given w ← default_world()
+	     And p ← point(-20, 20, -20)
+	    Then is_shadowed(w, p) is false */
+	w := rt.DefaultWorld()
+	p := rt.NewPoint(-20, 20, -20)
+
+	if w.IsShadowed(p, w.Lights[0]) {
+		t.Errorf("Error: %v", p)
+	}
+}
+
+func TestIsShadowedObjectBehindPoint(t *testing.T) {
+	/* Scenario: There is no shadow when an object is behind the point
+	   Given w ← default_world()
+	     And p ← point(-2, 2, -2)
+	    Then is_shadowed(w, p) is false */
+	w := rt.DefaultWorld()
+	p := rt.NewPoint(-2, 2, -2)
+
+	if w.IsShadowed(p, w.Lights[0]) {
+		t.Errorf("Error: %v", p)
+	}
+}
+
+func TestShadeHitInShadow(t *testing.T) {
+	/* Scenario: shade_hit() is given an intersection in shadow
+	   Given w ← world()
+	     And w.light ← point_light(point(0, 0, -10), color(1, 1, 1))
+	     And s1 ← sphere()
+	     And s1 is added to w
+	     And s2 ← sphere() with:
+	       | transform | translation(0, 0, 10) |
+	     And s2 is added to w
+	     And r ← ray(point(0, 0, 5), vector(0, 0, 1))
+	     And i ← intersection(4, s2)
+	   When comps ← prepare_computations(i, r)
+	     And c ← shade_hit(w, comps)
+	   Then c = color(0.1, 0.1, 0.1) */
+	w := rt.NewWorld()
+	w.AddLight(rt.NewPointLight(rt.NewPoint(0, 0, -10), &rt.Color{1, 1, 1}))
+	s1 := rt.NewSphere()
+	w.AddObject(s1)
+	s2 := rt.NewSphere()
+	s2.SetTransform(rt.Translation(0, 0, 10))
+	w.AddObject(s2)
+	r := rt.NewRay(rt.NewPoint(0, 0, 5), rt.NewVector(0, 0, 1))
+	i := rt.NewIntersection(4, s2)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+	c := w.ShadeHit(comps, rt.DefaultMaxDepth)
+
+	if !c.Equals(&rt.Color{0.1, 0.1, 0.1}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestReflectedColorNonReflective(t *testing.T) {
+	/* Scenario: The reflected color for a nonreflective material
+	   Given w ← default_world()
+	     And r ← ray(point(0, 0, 0), vector(0, 0, 1))
+	     And shape ← the second object in w
+	     And shape.material.ambient ← 1
+	     And i ← intersection(1, shape)
+	   When comps ← prepare_computations(i, r)
+	     And color ← reflected_color(w, comps)
+	   Then color = color(0, 0, 0) */
+	w := rt.DefaultWorld()
+	r := rt.NewRay(rt.NewPoint(0, 0, 0), rt.NewVector(0, 0, 1))
+	shape := w.Objects[1]
+	shape.GetMaterial().Ambient = 1
+	i := rt.NewIntersection(1, shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+	color := w.ReflectedColor(comps, rt.DefaultMaxDepth)
+
+	if !color.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestReflectedColorReflective(t *testing.T) {
+	/* Scenario: The reflected color for a reflective material
+	   Given w ← default_world()
+	     And shape ← plane() with:
+	       | material.reflective | 0.5                   |
+	       | transform           | translation(0, -1, 0) |
+	     And shape is added to w
+	     And r ← ray(point(0, 0, -3), vector(0, -√2/2, √2/2))
+	     And i ← intersection(√2, shape)
+	   When comps ← prepare_computations(i, r)
+	     And color ← reflected_color(w, comps)
+	   Then color = color(0.19032, 0.2379, 0.14274) */
+	w := rt.DefaultWorld()
+	shape := rt.NewPlane()
+	shape.Material.Reflective = 0.5
+	shape.SetTransform(rt.Translation(0, -1, 0))
+	w.AddObject(shape)
+	r := rt.NewRay(rt.NewPoint(0, 0, -3), rt.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := rt.NewIntersection(math.Sqrt(2), shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+	color := w.ReflectedColor(comps, rt.DefaultMaxDepth)
+
+	if math.Abs(color.R-0.19032) > 0.0001 || math.Abs(color.G-0.2379) > 0.0001 || math.Abs(color.B-0.14274) > 0.0001 {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestShadeHitReflective(t *testing.T) {
+	/* Scenario: shade_hit() with a reflective material
+	   Given w ← default_world()
+	     And shape ← plane() with:
+	       | material.reflective | 0.5                   |
+	       | transform           | translation(0, -1, 0) |
+	     And shape is added to w
+	     And r ← ray(point(0, 0, -3), vector(0, -√2/2, √2/2))
+	     And i ← intersection(√2, shape)
+	   When comps ← prepare_computations(i, r)
+	     And color ← shade_hit(w, comps)
+	   Then color = color(0.87677, 0.92436, 0.82918) */
+	w := rt.DefaultWorld()
+	shape := rt.NewPlane()
+	shape.Material.Reflective = 0.5
+	shape.SetTransform(rt.Translation(0, -1, 0))
+	w.AddObject(shape)
+	r := rt.NewRay(rt.NewPoint(0, 0, -3), rt.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := rt.NewIntersection(math.Sqrt(2), shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+	color := w.ShadeHit(comps, rt.DefaultMaxDepth)
+
+	if math.Abs(color.R-0.87677) > 0.0001 || math.Abs(color.G-0.92436) > 0.0001 || math.Abs(color.B-0.82918) > 0.0001 {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestColorAtMutuallyReflective(t *testing.T) {
+	/* Scenario: color_at() with mutually reflective surfaces
+	   Given w ← world()
+	     And w.light ← point_light(point(0, 0, 0), color(1, 1, 1))
+	     And lower ← plane() with:
+	       | material.reflective | 1                     |
+	       | transform           | translation(0, -1, 0) |
+	     And lower is added to w
+	     And upper ← plane() with:
+	       | material.reflective | 1                    |
+	       | transform           | translation(0, 1, 0) |
+	     And upper is added to w
+	     And r ← ray(point(0, 0, 0), vector(0, 1, 0))
+	   Then color_at(w, r) should terminate successfully */
+	w := rt.NewWorld()
+	w.AddLight(rt.NewPointLight(rt.NewPoint(0, 0, 0), &rt.Color{1, 1, 1}))
+	lower := rt.NewPlane()
+	lower.Material.Reflective = 1
+	lower.SetTransform(rt.Translation(0, -1, 0))
+	upper := rt.NewPlane()
+	upper.Material.Reflective = 1
+	upper.SetTransform(rt.Translation(0, 1, 0))
+	w.AddObject(lower, upper)
+	r := rt.NewRay(rt.NewPoint(0, 0, 0), rt.NewVector(0, 1, 0))
+
+	color := w.ColorAt(r)
+
+	if color == nil {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestReflectedColorMaxDepth(t *testing.T) {
+	/* Scenario: The reflected color at the maximum recursive depth
+	   Given w ← default_world()
+	     And shape ← plane() with:
+	       | material.reflective | 0.5                   |
+	       | transform           | translation(0, -1, 0) |
+	     And shape is added to w
+	     And r ← ray(point(0, 0, -3), vector(0, -√2/2, √2/2))
+	     And i ← intersection(√2, shape)
+	   When comps ← prepare_computations(i, r)
+	     And color ← reflected_color(w, comps, 0)
+	   Then color = color(0, 0, 0) */
+	w := rt.DefaultWorld()
+	shape := rt.NewPlane()
+	shape.Material.Reflective = 0.5
+	shape.SetTransform(rt.Translation(0, -1, 0))
+	w.AddObject(shape)
+	r := rt.NewRay(rt.NewPoint(0, 0, -3), rt.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := rt.NewIntersection(math.Sqrt(2), shape)
+
+	comps := i.PrepareComputations(r, rt.NewIntersections(i))
+	color := w.ReflectedColor(comps, 0)
+
+	if !color.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestRefractedColorOpaque(t *testing.T) {
+	/* Scenario: The refracted color with an opaque surface
+	   Given w ← default_world()
+	     And shape ← the first object in w
+	     And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And xs ← intersections(4:shape, 6:shape)
+	   When comps ← prepare_computations(xs[0], r, xs)
+	     And c ← refracted_color(w, comps, 5)
+	   Then c = color(0, 0, 0) */
+	w := rt.DefaultWorld()
+	shape := w.Objects[0]
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	xs := rt.NewIntersections(rt.NewIntersection(4, shape), rt.NewIntersection(6, shape))
+
+	comps := xs[0].PrepareComputations(r, xs)
+	c := w.RefractedColor(comps, 5)
+
+	if !c.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestRefractedColorMaxDepth(t *testing.T) {
+	/* Scenario: The refracted color at the maximum recursive depth
+	   Given w ← default_world()
+	     And shape ← the first object in w
+	     And shape has:
+	       | material.transparency     | 1.0 |
+	       | material.refractive_index | 1.5 |
+	     And r ← ray(point(0, 0, -5), vector(0, 0, 1))
+	     And xs ← intersections(4:shape, 6:shape)
+	   When comps ← prepare_computations(xs[0], r, xs)
+	     And c ← refracted_color(w, comps, 0)
+	   Then c = color(0, 0, 0) */
+	w := rt.DefaultWorld()
+	shape := w.Objects[0]
+	shape.GetMaterial().Transparency = 1.0
+	shape.GetMaterial().RefractiveIndex = 1.5
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+	xs := rt.NewIntersections(rt.NewIntersection(4, shape), rt.NewIntersection(6, shape))
+
+	comps := xs[0].PrepareComputations(r, xs)
+	c := w.RefractedColor(comps, 0)
+
+	if !c.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestRefractedColorTotalInternalReflection(t *testing.T) {
+	/* Scenario: The refracted color under total internal reflection
+	   Given w ← default_world()
+	     And shape ← the first object in w
+	     And shape has:
+	       | material.transparency     | 1.0 |
+	       | material.refractive_index | 1.5 |
+	     And r ← ray(point(0, 0, √2/2), vector(0, 1, 0))
+	     And xs ← intersections(-√2/2:shape, √2/2:shape)
+	   When comps ← prepare_computations(xs[1], r, xs)
+	     And c ← refracted_color(w, comps, 5)
+	   Then c = color(0, 0, 0) */
+	w := rt.DefaultWorld()
+	shape := w.Objects[0]
+	shape.GetMaterial().Transparency = 1.0
+	shape.GetMaterial().RefractiveIndex = 1.5
+	r := rt.NewRay(rt.NewPoint(0, 0, math.Sqrt(2)/2), rt.NewVector(0, 1, 0))
+	xs := rt.NewIntersections(
+		rt.NewIntersection(-math.Sqrt(2)/2, shape),
+		rt.NewIntersection(math.Sqrt(2)/2, shape),
+	)
+
+	comps := xs[1].PrepareComputations(r, xs)
+	c := w.RefractedColor(comps, 5)
+
+	if !c.Equals(&rt.Color{0, 0, 0}) {
+		t.Errorf("Error: %v", c)
+	}
+}
+
+func TestShadeHitTransparent(t *testing.T) {
+	/* Scenario: shade_hit() with a transparent material
+	   Given w ← default_world()
+	     And floor ← plane() with:
+	       | transform                 | translation(0, -1, 0) |
+	       | material.transparency     | 0.5                   |
+	       | material.refractive_index | 1.5                   |
+	     And floor is added to w
+	     And ball ← sphere() with:
+	       | material.color     | (1, 0, 0)                  |
+	       | material.ambient   | 0.5                        |
+	       | transform          | translation(0, -3.5, -0.5) |
+	     And ball is added to w
+	     And r ← ray(point(0, 0, -3), vector(0, -√2/2, √2/2))
+	     And xs ← intersections(√2:floor)
+	   When comps ← prepare_computations(xs[0], r, xs)
+	     And color ← shade_hit(w, comps, 5)
+	   Then color = color(0.93642, 0.68642, 0.68642) */
+	w := rt.DefaultWorld()
+	floor := rt.NewPlane()
+	floor.SetTransform(rt.Translation(0, -1, 0))
+	floor.Material.Transparency = 0.5
+	floor.Material.RefractiveIndex = 1.5
+	w.AddObject(floor)
+	ball := rt.NewSphere()
+	ball.Material.Color = &rt.Color{1, 0, 0}
+	ball.Material.Ambient = 0.5
+	ball.SetTransform(rt.Translation(0, -3.5, -0.5))
+	w.AddObject(ball)
+	r := rt.NewRay(rt.NewPoint(0, 0, -3), rt.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	xs := rt.NewIntersections(rt.NewIntersection(math.Sqrt(2), floor))
+
+	comps := xs[0].PrepareComputations(r, xs)
+	color := w.ShadeHit(comps, 5)
+
+	if math.Abs(color.R-0.93642) > 0.0001 || math.Abs(color.G-0.68642) > 0.0001 || math.Abs(color.B-0.68642) > 0.0001 {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestShadeHitSchlick(t *testing.T) {
+	/* Scenario: shade_hit() with a reflective, transparent material
+	   Given w ← default_world()
+	     And r ← ray(point(0, 0, -3), vector(0, -√2/2, √2/2))
+	     And floor ← plane() with:
+	       | transform                 | translation(0, -1, 0) |
+	       | material.reflective       | 0.5                   |
+	       | material.transparency     | 0.5                   |
+	       | material.refractive_index | 1.5                   |
+	     And floor is added to w
+	     And ball ← sphere() with:
+	       | material.color     | (1, 0, 0)                  |
+	       | material.ambient   | 0.5                        |
+	       | transform          | translation(0, -3.5, -0.5) |
+	     And ball is added to w
+	     And xs ← intersections(√2:floor)
+	   When comps ← prepare_computations(xs[0], r, xs)
+	     And color ← shade_hit(w, comps, 5)
+	   Then color = color(0.93391, 0.69643, 0.69243) */
+	w := rt.DefaultWorld()
+	r := rt.NewRay(rt.NewPoint(0, 0, -3), rt.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	floor := rt.NewPlane()
+	floor.SetTransform(rt.Translation(0, -1, 0))
+	floor.Material.Reflective = 0.5
+	floor.Material.Transparency = 0.5
+	floor.Material.RefractiveIndex = 1.5
+	w.AddObject(floor)
+	ball := rt.NewSphere()
+	ball.Material.Color = &rt.Color{1, 0, 0}
+	ball.Material.Ambient = 0.5
+	ball.SetTransform(rt.Translation(0, -3.5, -0.5))
+	w.AddObject(ball)
+	xs := rt.NewIntersections(rt.NewIntersection(math.Sqrt(2), floor))
+
+	comps := xs[0].PrepareComputations(r, xs)
+	color := w.ShadeHit(comps, 5)
+
+	if math.Abs(color.R-0.93391) > 0.0001 || math.Abs(color.G-0.69643) > 0.0001 || math.Abs(color.B-0.69243) > 0.0001 {
+		t.Errorf("Error: %v", color)
+	}
+}
+
+func TestTracerColorAt(t *testing.T) {
+	/* Scenario: A tracer shades rays like its world
+	   Given w ← default_world() with a reflective sphere
+	     And a transparent floor at y = -1
+	     And tracer ← tracer(w)
+	   Then color_at(tracer, r) = color_at(w, r) for rays that hit a sphere,
+	     the floor or nothing */
+	w := rt.DefaultWorld()
+	w.Objects[0].GetMaterial().Reflective = 0.5
+	floor := rt.NewPlane()
+	floor.SetTransform(rt.Translation(0, -1, 0))
+	floor.GetMaterial().Transparency = 0.5
+	floor.GetMaterial().RefractiveIndex = 1.5
+	w.AddObject(floor)
+
+	tracer := rt.NewTracer(w)
+	for _, r := range []rt.Ray{
+		rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1)),
+		rt.NewRay(rt.NewPoint(0, 2, -5), rt.NewVector(0, -0.6, 0.8)),
+		rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 1, 0)),
+	} {
+		if c, expected := tracer.ColorAt(r), w.ColorAt(r); !c.Equals(expected) {
+			t.Errorf("Error: %v %v", c, expected)
+		}
+	}
+}
+
+func TestTracerReusesIntersections(t *testing.T) {
+	/* Scenario: A tracer reuses its list of intersections
+	   Given w ← default_world()
+	     And tracer ← tracer(w) after tracing r once
+	   Then intersecting r with w allocates nothing
+	     And shading r allocates less than with a new list every time
+	     And it allocates as much after adding spheres behind the hit */
+	w := rt.DefaultWorld()
+	r := rt.NewRay(rt.NewPoint(0, 0, -5), rt.NewVector(0, 0, 1))
+
+	tracer := rt.NewTracer(w)
+	tracer.ColorAt(r)
+
+	if allocs := testing.AllocsPerRun(100, func() { tracer.IntersectWorld(r) }); allocs != 0 {
+		t.Errorf("Error: %v", allocs)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() { tracer.ColorAt(r) })
+	if fresh := testing.AllocsPerRun(100, func() { w.ColorAt(r) }); fresh <= allocs {
+		t.Errorf("Error: %v %v", fresh, allocs)
+	}
+
+	// Spheres behind the hit make the list of intersections longer, but
+	// tracing the ray allocates as much as before once the list has grown.
+	for z := 0; z < 6; z++ {
+		s := rt.NewSphere()
+		s.SetTransform(rt.Translation(0, 0, float64(3+z*3)))
+		w.AddObject(s)
+	}
+	tracer.ColorAt(r)
+
+	if more := testing.AllocsPerRun(100, func() { tracer.ColorAt(r) }); more != allocs {
+		t.Errorf("Error: %v %v", more, allocs)
+	}
+}
+
+func BenchmarkColorAt(b *testing.B) {
+	w := rt.DefaultWorld()
+	w.Objects[0].GetMaterial().Reflective = 0.5
+	floor := rt.NewPlane()
+	floor.SetTransform(rt.Translation(0, -1, 0))
+	w.AddObject(floor)
+
+	r := rt.NewRay(rt.NewPoint(0, 1.5, -5), rt.NewVector(0, -0.3, 1).Norm())
+
+	b.Run("world", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			w.ColorAt(r)
+		}
+	})
+
+	b.Run("tracer", func(b *testing.B) {
+		b.ReportAllocs()
+		tracer := rt.NewTracer(w)
+		for i := 0; i < b.N; i++ {
+			tracer.ColorAt(r)
+		}
+	})
+}